@@ -0,0 +1,50 @@
+package spreadsheet
+
+import "context"
+
+// BandedRange is a range of a sheet whose rows or columns are colored in alternating bands.
+type BandedRange struct {
+	BandedRangeID    int                `json:"bandedRangeId,omitempty"`
+	Range            GridRange          `json:"range"`
+	RowProperties    *BandingProperties `json:"rowProperties,omitempty"`
+	ColumnProperties *BandingProperties `json:"columnProperties,omitempty"`
+}
+
+// BandingProperties are the colors of the bands of rows or columns.
+// The header and the footer are the first and the last row or column, colored only when their color is set.
+type BandingProperties struct {
+	HeaderColor     *Color `json:"headerColor,omitempty"`
+	FirstBandColor  *Color `json:"firstBandColor,omitempty"`
+	SecondBandColor *Color `json:"secondBandColor,omitempty"`
+	FooterColor     *Color `json:"footerColor,omitempty"`
+}
+
+// BandedRanges returns the banded ranges of the sheet as of when it was fetched.
+func (sheet *Sheet) BandedRanges() []BandedRange {
+	return append([]BandedRange(nil), sheet.bandedRanges...)
+}
+
+// DeleteBanding deletes the banded range with the ID from the sheet, e.g. one of Sheet.BandedRanges.
+func (s *Service) DeleteBanding(sheet *Sheet, bandedRangeID int) (err error) {
+	err = s.DeleteBandingContext(context.Background(), sheet, bandedRangeID)
+	return
+}
+
+// DeleteBandingContext is like DeleteBanding with the context of the request.
+func (s *Service) DeleteBandingContext(ctx context.Context, sheet *Sheet, bandedRangeID int) (err error) {
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	_, err = r.DeleteBanding(bandedRangeID).Do(ctx)
+	if err != nil {
+		return
+	}
+	for i, bandedRange := range sheet.bandedRanges {
+		if bandedRange.BandedRangeID == bandedRangeID {
+			sheet.bandedRanges = append(sheet.bandedRanges[:i:i], sheet.bandedRanges[i+1:]...)
+			break
+		}
+	}
+	return
+}
@@ -0,0 +1,58 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSheetBandedRanges(t *testing.T) {
+	assert := assert.New(t)
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Requests []map[string]interface{} `json:"requests"`
+		}
+		assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+		requests = body.Requests
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	spreadsheet := &Spreadsheet{ID: "abc", service: s, Sheets: []Sheet{{}}}
+	sheet := &spreadsheet.Sheets[0]
+	err := json.Unmarshal([]byte(`{
+		"properties": {"sheetId": 7},
+		"bandedRanges": [
+			{
+				"bandedRangeId": 3,
+				"range": {"sheetId": 7, "endRowIndex": 10, "endColumnIndex": 4},
+				"rowProperties": {"headerColor": {"blue": 1, "alpha": 1}, "firstBandColor": {"red": 1, "green": 1, "blue": 1, "alpha": 1}}
+			},
+			{"bandedRangeId": 4, "range": {"sheetId": 7, "startColumnIndex": 6}}
+		]
+	}`), sheet)
+	assert.NoError(err)
+	sheet.Spreadsheet = spreadsheet
+
+	bandedRanges := sheet.BandedRanges()
+	assert.Len(bandedRanges, 2)
+	assert.Equal(BandedRange{
+		BandedRangeID: 3,
+		Range:         GridRange{SheetID: 7, EndRowIndex: 10, EndColumnIndex: 4},
+		RowProperties: &BandingProperties{
+			HeaderColor:    &Color{Blue: 1, Alpha: 1},
+			FirstBandColor: &Color{Red: 1, Green: 1, Blue: 1, Alpha: 1},
+		},
+	}, bandedRanges[0])
+
+	assert.NoError(s.DeleteBanding(sheet, 3))
+	assert.Equal([]map[string]interface{}{{"deleteBanding": map[string]interface{}{"bandedRangeId": 3.0}}}, requests)
+	bandedRanges = sheet.BandedRanges()
+	assert.Len(bandedRanges, 1)
+	assert.Equal(4, bandedRanges[0].BandedRangeID)
+}
@@ -0,0 +1,110 @@
+package spreadsheet
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ReadThroughCache caches the results of expensive lookups in a sheet,
+// with keys in the first column and values in the second column.
+// Entries can be inspected and edited in the sheet, and are shared
+// by all processes using the same sheet.
+type ReadThroughCache struct {
+	Service *Service
+	Ref     SheetRef
+	// Loader looks up the value of a key missing in the sheet.
+	Loader func(key string) (string, error)
+
+	mu     sync.Mutex
+	values map[string]string
+	fetch  func(ctx context.Context) ([][]string, error)
+	store  func(ctx context.Context, key, value string) error
+}
+
+// Get returns the value of the key from the sheet. On a miss the value is
+// looked up by the Loader and appended to the sheet.
+func (c *ReadThroughCache) Get(key string) (value string, err error) {
+	value, err = c.GetContext(context.Background(), key)
+	return
+}
+
+// GetContext is like Get with the context of the request.
+func (c *ReadThroughCache) GetContext(ctx context.Context, key string) (value string, err error) {
+	c.mu.Lock()
+	if c.values == nil {
+		err = c.refresh(ctx)
+	}
+	value, ok := c.values[key]
+	c.mu.Unlock()
+	if err != nil || ok {
+		return
+	}
+	if c.Loader == nil {
+		err = errors.New("Loader must not be nil")
+		return
+	}
+	value, err = c.Loader(key)
+	if err != nil {
+		return
+	}
+	err = c.storeFunc()(ctx, key, value)
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	c.values[key] = value
+	c.mu.Unlock()
+	return
+}
+
+// Refresh reloads the entries from the sheet, e.g. after they were edited or added by others.
+func (c *ReadThroughCache) Refresh() error {
+	return c.RefreshContext(context.Background())
+}
+
+// RefreshContext is like Refresh with the context of the request.
+func (c *ReadThroughCache) RefreshContext(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.refresh(ctx)
+}
+
+func (c *ReadThroughCache) refresh(ctx context.Context) error {
+	rows, err := c.fetchFunc()(ctx)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]string, len(rows))
+	for _, row := range rows {
+		if len(row) > 0 && row[0] != "" {
+			values[row[0]] = valueOrEmpty(row, 1)
+		}
+	}
+	c.values = values
+	return nil
+}
+
+func (c *ReadThroughCache) fetchFunc() func(context.Context) ([][]string, error) {
+	if c.fetch != nil {
+		return c.fetch
+	}
+	return func(ctx context.Context) (rows [][]string, err error) {
+		valueRange, err := c.Service.GetValuesContext(ctx, c.Ref.SpreadsheetID, quoteSheetTitle(c.Ref.Title)+"!A:B")
+		if err != nil {
+			return
+		}
+		rows = toStrings(valueRange.Values)
+		return
+	}
+}
+
+func (c *ReadThroughCache) storeFunc() func(ctx context.Context, key, value string) error {
+	if c.store != nil {
+		return c.store
+	}
+	return func(ctx context.Context, key, value string) error {
+		_, err := c.Service.AtomicAppendContext(ctx, c.Ref, [][]interface{}{{key, value}}, WithValueInputOption(ValueInputRaw))
+		return err
+	}
+}
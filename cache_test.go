@@ -0,0 +1,55 @@
+package spreadsheet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadThroughCache(t *testing.T) {
+	assert := assert.New(t)
+	rows := [][]string{{"a", "1"}, {"b"}, {}}
+	loads := 0
+	c := &ReadThroughCache{
+		Loader: func(key string) (string, error) {
+			loads++
+			if key == "bad" {
+				return "", errors.New("lookup failed")
+			}
+			return key + "!", nil
+		},
+		fetch: func(context.Context) ([][]string, error) { return rows, nil },
+		store: func(ctx context.Context, key, value string) error {
+			rows = append(rows, []string{key, value})
+			return nil
+		},
+	}
+
+	value, err := c.Get("a")
+	assert.NoError(err)
+	assert.Equal("1", value)
+	value, err = c.Get("b")
+	assert.NoError(err)
+	assert.Equal("", value)
+	assert.Equal(0, loads)
+
+	value, err = c.Get("c")
+	assert.NoError(err)
+	assert.Equal("c!", value)
+	value, err = c.Get("c")
+	assert.NoError(err)
+	assert.Equal("c!", value)
+	assert.Equal(1, loads)
+	assert.Equal([]string{"c", "c!"}, rows[len(rows)-1])
+
+	_, err = c.Get("bad")
+	assert.EqualError(err, "lookup failed")
+
+	rows = [][]string{{"d", "edited"}}
+	assert.NoError(c.Refresh())
+	value, err = c.Get("d")
+	assert.NoError(err)
+	assert.Equal("edited", value)
+}
@@ -7,9 +7,74 @@ type Cell struct {
 	Row    uint
 	Column uint
 	Value  string
+	// UserEnteredValue is the typed value entered in the cell, e.g. a number or a formula.
+	// It's nil for empty cells and for cells changed by Sheet.Update.
+	UserEnteredValue *ExtendedValue
+	// FormattedValue is the value displayed in the cell, e.g. "$1.50" or the result of a formula,
+	// as fetched. Value holds it too unless WithValueRenderOption renders the values otherwise.
+	FormattedValue string
+	// EffectiveValue is the calculated value of the cell, e.g. the number a formula evaluates to,
+	// as fetched WithEffectiveValues.
+	EffectiveValue *ExtendedValue
+	// Hyperlink is the link of the cell, e.g. of a HYPERLINK formula or of the whole text of the cell.
+	Hyperlink string
 }
 
 // Pos returns the cell's position like "A1"
 func (cell *Cell) Pos() string {
 	return numberToLetter(int(cell.Column)+1) + fmt.Sprintf("%d", cell.Row+1)
 }
+
+// isEmpty reports whether the cell has none of the fetched fields.
+func (cell *Cell) isEmpty() bool {
+	return cell.Value == "" && cell.UserEnteredValue == nil && cell.EffectiveValue == nil && cell.Hyperlink == ""
+}
+
+// NumberValue returns the number entered in the cell, if it's a number.
+func (cell *Cell) NumberValue() (number float64, ok bool) {
+	if v := cell.UserEnteredValue; v != nil && v.IsNumber() {
+		number, ok = v.NumberValue, true
+	}
+	return
+}
+
+// BoolValue returns the boolean entered in the cell, if it's a boolean.
+func (cell *Cell) BoolValue() (b bool, ok bool) {
+	if v := cell.UserEnteredValue; v != nil && v.IsBool() {
+		b, ok = v.BoolValue, true
+	}
+	return
+}
+
+// StringValue returns the text entered in the cell, if it's a text.
+func (cell *Cell) StringValue() (s string, ok bool) {
+	if v := cell.UserEnteredValue; v != nil && v.StringValue != "" {
+		s, ok = v.StringValue, true
+	}
+	return
+}
+
+// FormulaValue returns the formula entered in the cell, if it has one.
+func (cell *Cell) FormulaValue() (formula string, ok bool) {
+	if v := cell.UserEnteredValue; v != nil && v.FormulaValue != "" {
+		formula, ok = v.FormulaValue, true
+	}
+	return
+}
+
+// input returns the value written for the cell: the typed value entered, or Value
+// to be interpreted by the value input option.
+func (cell *Cell) input() interface{} {
+	v := cell.UserEnteredValue
+	switch {
+	case v == nil:
+		return cell.Value
+	case v.IsNumber():
+		return v.NumberValue
+	case v.IsBool():
+		return v.BoolValue
+	case v.FormulaValue != "":
+		return v.FormulaValue
+	}
+	return v.StringValue
+}
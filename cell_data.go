@@ -1,15 +1,27 @@
 package spreadsheet
 
+import "encoding/json"
+
 // CellData is data about a specific cell.
 type CellData struct {
-	UserEnteredValue ExtendedValue `json:"userEnteredValue"`
-	EffectiveValue   ExtendedValue `json:"effectiveValue"`
-	FormattedValue   string        `json:"formattedValue"`
-	// UserEnteredFormat *CellFormat   `json:"userEnteredFormat"`
+	UserEnteredValue  ExtendedValue `json:"userEnteredValue"`
+	EffectiveValue    ExtendedValue `json:"effectiveValue"`
+	FormattedValue    string        `json:"formattedValue,omitempty"`
+	UserEnteredFormat *CellFormat   `json:"userEnteredFormat,omitempty"`
 	// EffectiveFormat *CellFormat `json:"effectiveFormat"`
-	Hyperlink string `json:"hyperlink"`
-	Note      string `json:"note"`
+	Hyperlink string `json:"hyperlink,omitempty"`
+	Note      string `json:"note,omitempty"`
 	// TextFormatRuns []*TextFormatRun `json:"textFormatRuns"`
-	// DataValidation *DataValidationRule `json:"dataValidation"`
+	DataValidation *DataValidationRule `json:"dataValidation,omitempty"`
 	// PivotTable *PivotTable `json:"pivotTable"`
 }
+
+// MarshalJSON omits the values which aren't set, so that writing the cell data only writes what it has.
+func (cellData CellData) MarshalJSON() ([]byte, error) {
+	type Alias CellData
+	return json.Marshal(struct {
+		UserEnteredValue *ExtendedValue `json:"userEnteredValue,omitempty"`
+		EffectiveValue   *ExtendedValue `json:"effectiveValue,omitempty"`
+		Alias
+	}{cellData.UserEnteredValue.pointer(), cellData.EffectiveValue.pointer(), Alias(cellData)})
+}
@@ -0,0 +1,40 @@
+package spreadsheet
+
+// CellFormat is the format of a cell.
+type CellFormat struct {
+	NumberFormat        *NumberFormat       `json:"numberFormat,omitempty"`
+	BackgroundColor     *Color              `json:"backgroundColor,omitempty"`
+	HorizontalAlignment HorizontalAlignment `json:"horizontalAlignment,omitempty"`
+	VerticalAlignment   VerticalAlignment   `json:"verticalAlignment,omitempty"`
+	WrapStrategy        WrapStrategy        `json:"wrapStrategy,omitempty"`
+	TextFormat          *TextFormat         `json:"textFormat,omitempty"`
+	TextRotation        *TextRotation       `json:"textRotation,omitempty"`
+}
+
+// TextRotation is the rotation of the text of a cell, either an angle or vertical text.
+type TextRotation struct {
+	// Angle is the angle in degrees between the text and the horizontal, from -90 to 90.
+	// Positive angles are counterclockwise.
+	Angle int `json:"angle,omitempty"`
+	// Vertical stacks the characters of the text top to bottom.
+	Vertical bool `json:"vertical,omitempty"`
+}
+
+// NumberFormat is how numbers are displayed in a cell.
+type NumberFormat struct {
+	Type NumberFormatType `json:"type"`
+	// Pattern is the pattern of the type, e.g. "#,##0.00 [$€]" or "yyyy-mm-dd".
+	// The pattern of the locale of the spreadsheet is used when it is empty.
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// TextFormat is the format of a run of text in a cell.
+type TextFormat struct {
+	ForegroundColor *Color `json:"foregroundColor,omitempty"`
+	FontFamily      string `json:"fontFamily,omitempty"`
+	FontSize        int    `json:"fontSize,omitempty"`
+	Bold            bool   `json:"bold,omitempty"`
+	Italic          bool   `json:"italic,omitempty"`
+	Strikethrough   bool   `json:"strikethrough,omitempty"`
+	Underline       bool   `json:"underline,omitempty"`
+}
@@ -0,0 +1,84 @@
+package spreadsheet
+
+// EmbeddedChart is a chart embedded in a sheet.
+type EmbeddedChart struct {
+	ChartID  uint                   `json:"chartId,omitempty"`
+	Spec     ChartSpec              `json:"spec"`
+	Position EmbeddedObjectPosition `json:"position"`
+}
+
+// ChartSpec is the specification of a chart.
+type ChartSpec struct {
+	Title      string          `json:"title,omitempty"`
+	BasicChart *BasicChartSpec `json:"basicChart,omitempty"`
+}
+
+// BasicChartSpec is the specification of a basic chart such as a bar, column or line chart.
+type BasicChartSpec struct {
+	ChartType      ChartType          `json:"chartType"`
+	LegendPosition LegendPosition     `json:"legendPosition,omitempty"`
+	Axis           []BasicChartAxis   `json:"axis,omitempty"`
+	Domains        []BasicChartDomain `json:"domains,omitempty"`
+	Series         []BasicChartSeries `json:"series,omitempty"`
+	HeaderCount    int                `json:"headerCount,omitempty"`
+}
+
+// BasicChartAxis is an axis of a basic chart.
+type BasicChartAxis struct {
+	Position AxisPosition `json:"position"`
+	Title    string       `json:"title,omitempty"`
+}
+
+// BasicChartDomain is the domain of a basic chart, e.g. the categories of a bar chart.
+type BasicChartDomain struct {
+	Domain ChartData `json:"domain"`
+}
+
+// BasicChartSeries is a series of data of a basic chart.
+type BasicChartSeries struct {
+	Series     ChartData    `json:"series"`
+	TargetAxis AxisPosition `json:"targetAxis,omitempty"`
+}
+
+// ChartData is the data of a domain or a series.
+type ChartData struct {
+	SourceRange ChartSourceRange `json:"sourceRange"`
+}
+
+// ChartSourceRange is the ranges of data of a chart.
+type ChartSourceRange struct {
+	Sources []GridRange `json:"sources"`
+}
+
+// EmbeddedObjectPosition is the position of an embedded object such as a chart.
+type EmbeddedObjectPosition struct {
+	SheetID         uint             `json:"sheetId,omitempty"`
+	OverlayPosition *OverlayPosition `json:"overlayPosition,omitempty"`
+	NewSheet        bool             `json:"newSheet,omitempty"`
+}
+
+// OverlayPosition is the position of an object floating over a grid.
+type OverlayPosition struct {
+	AnchorCell    GridCoordinate `json:"anchorCell"`
+	OffsetXPixels int            `json:"offsetXPixels,omitempty"`
+	OffsetYPixels int            `json:"offsetYPixels,omitempty"`
+	WidthPixels   int            `json:"widthPixels,omitempty"`
+	HeightPixels  int            `json:"heightPixels,omitempty"`
+}
+
+// Charts returns the charts embedded in the sheet as of when it was fetched.
+func (sheet *Sheet) Charts() []EmbeddedChart {
+	return append([]EmbeddedChart(nil), sheet.charts...)
+}
+
+// ChartByTitle returns the first chart embedded in the sheet with the title, if any,
+// e.g. to update an existing chart instead of adding a duplicate.
+func (sheet *Sheet) ChartByTitle(title string) (chart EmbeddedChart, ok bool) {
+	for _, c := range sheet.charts {
+		if c.Spec.Title == title {
+			chart, ok = c, true
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,46 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSheetCharts(t *testing.T) {
+	assert := assert.New(t)
+	sheet := &Sheet{}
+	err := json.Unmarshal([]byte(`{
+		"properties": {"sheetId": 7},
+		"charts": [{
+			"chartId": 42,
+			"spec": {
+				"title": "Sales",
+				"basicChart": {
+					"chartType": "COLUMN",
+					"legendPosition": "BOTTOM_LEGEND",
+					"domains": [{"domain": {"sourceRange": {"sources": [{"sheetId": 7, "endRowIndex": 5, "endColumnIndex": 1}]}}}],
+					"series": [{"series": {"sourceRange": {"sources": [{"sheetId": 7, "endRowIndex": 5, "startColumnIndex": 1, "endColumnIndex": 2}]}}, "targetAxis": "LEFT_AXIS"}],
+					"headerCount": 1
+				}
+			},
+			"position": {"overlayPosition": {"anchorCell": {"sheetId": 7, "rowIndex": 1, "columnIndex": 4}, "widthPixels": 600}}
+		}]
+	}`), sheet)
+	assert.NoError(err)
+
+	charts := sheet.Charts()
+	assert.Len(charts, 1)
+	assert.Equal(uint(42), charts[0].ChartID)
+	assert.Equal(ChartColumn, charts[0].Spec.BasicChart.ChartType)
+	assert.Equal([]GridRange{{SheetID: 7, EndRowIndex: 5, StartColumnIndex: 1, EndColumnIndex: 2}},
+		charts[0].Spec.BasicChart.Series[0].Series.SourceRange.Sources)
+	assert.Equal(&OverlayPosition{AnchorCell: GridCoordinate{SheetID: 7, RowIndex: 1, ColumnIndex: 4}, WidthPixels: 600},
+		charts[0].Position.OverlayPosition)
+
+	chart, ok := sheet.ChartByTitle("Sales")
+	assert.True(ok)
+	assert.Equal(uint(42), chart.ChartID)
+	_, ok = sheet.ChartByTitle("Costs")
+	assert.False(ok)
+}
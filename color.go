@@ -0,0 +1,64 @@
+package spreadsheet
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Color represents a color in the RGBA color space.
+type Color struct {
+	Red   float32 `json:"red"`
+	Green float32 `json:"green"`
+	Blue  float32 `json:"blue"`
+	Alpha float32 `json:"alpha"`
+}
+
+// RGB returns the opaque color of the components from 0 to 255.
+func RGB(red, green, blue uint8) Color {
+	return Color{Red: float32(red) / 255, Green: float32(green) / 255, Blue: float32(blue) / 255, Alpha: 1}
+}
+
+// ParseHexColor parses a color in hexadecimal notation, e.g. "#d9534f", "d9534f", "#f00" or "#d9534f80" with alpha.
+// Colors without alpha are opaque.
+func ParseHexColor(hex string) (color Color, err error) {
+	s := strings.TrimPrefix(hex, "#")
+	if len(s) == 3 {
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) == 6 {
+		s += "ff"
+	}
+	if len(s) != 8 {
+		err = fmt.Errorf("invalid hex color: %q", hex)
+		return
+	}
+	n, e := strconv.ParseUint(s, 16, 32)
+	if e != nil {
+		err = fmt.Errorf("invalid hex color: %q", hex)
+		return
+	}
+	color = RGB(uint8(n>>24), uint8(n>>16), uint8(n>>8))
+	color.Alpha = float32(uint8(n)) / 255
+	return
+}
+
+// Hex returns the color in hexadecimal notation, e.g. "#d9534f", with the alpha when it's not opaque.
+func (c Color) Hex() string {
+	hex := fmt.Sprintf("#%02x%02x%02x", colorByte(c.Red), colorByte(c.Green), colorByte(c.Blue))
+	if a := colorByte(c.Alpha); a != 0xff {
+		hex += fmt.Sprintf("%02x", a)
+	}
+	return hex
+}
+
+func colorByte(component float32) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, float64(component))) * 255))
+}
+
+// ColorStyle is a color value, either an RGB color or a theme color.
+type ColorStyle struct {
+	RGBColor   *Color         `json:"rgbColor,omitempty"`
+	ThemeColor ThemeColorType `json:"themeColor,omitempty"`
+}
@@ -0,0 +1,29 @@
+package spreadsheet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHexColor(t *testing.T) {
+	assert := assert.New(t)
+	color, err := ParseHexColor("#ff0080")
+	assert.NoError(err)
+	assert.Equal(Color{Red: 1, Green: 0, Blue: float32(0x80) / 255, Alpha: 1}, color)
+	assert.Equal("#ff0080", color.Hex())
+
+	color, err = ParseHexColor("f00")
+	assert.NoError(err)
+	assert.Equal(RGB(255, 0, 0), color)
+
+	color, err = ParseHexColor("#d9534f80")
+	assert.NoError(err)
+	assert.Equal("#d9534f80", color.Hex())
+	assert.Equal("#000000", RGB(0, 0, 0).Hex())
+
+	for _, hex := range []string{"", "#ff00", "#gg0000", "#ff0000000"} {
+		_, err = ParseHexColor(hex)
+		assert.Error(err, hex)
+	}
+}
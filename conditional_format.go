@@ -0,0 +1,37 @@
+package spreadsheet
+
+// ConditionalFormatRule is a rule of conditional formatting of ranges of a sheet.
+// Either BooleanRule or GradientRule is set.
+type ConditionalFormatRule struct {
+	Ranges       []GridRange   `json:"ranges"`
+	BooleanRule  *BooleanRule  `json:"booleanRule,omitempty"`
+	GradientRule *GradientRule `json:"gradientRule,omitempty"`
+}
+
+// BooleanRule formats the cells which meet the condition.
+type BooleanRule struct {
+	Condition BooleanCondition `json:"condition"`
+	Format    CellFormat       `json:"format"`
+}
+
+// GradientRule colors the cells on a gradient between the colors of its points by their values.
+// Midpoint is optional.
+type GradientRule struct {
+	Minpoint InterpolationPoint  `json:"minpoint"`
+	Midpoint *InterpolationPoint `json:"midpoint,omitempty"`
+	Maxpoint InterpolationPoint  `json:"maxpoint"`
+}
+
+// InterpolationPoint is a point of a gradient.
+type InterpolationPoint struct {
+	Color *Color                 `json:"color,omitempty"`
+	Type  InterpolationPointType `json:"type"`
+	// Value is interpreted by the type, e.g. "10" for InterpolationPercent. It is empty for the min and the max.
+	Value string `json:"value,omitempty"`
+}
+
+// ConditionalFormats returns the rules of conditional formatting of the sheet as of when it was fetched,
+// in order of priority.
+func (sheet *Sheet) ConditionalFormats() []ConditionalFormatRule {
+	return append([]ConditionalFormatRule(nil), sheet.conditionalFormats...)
+}
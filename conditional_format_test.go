@@ -0,0 +1,52 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSheetConditionalFormats(t *testing.T) {
+	assert := assert.New(t)
+	sheet := &Sheet{}
+	err := json.Unmarshal([]byte(`{
+		"properties": {"sheetId": 7},
+		"conditionalFormats": [
+			{
+				"ranges": [{"sheetId": 7, "startColumnIndex": 1, "endColumnIndex": 2}],
+				"booleanRule": {
+					"condition": {"type": "NUMBER_GREATER", "values": [{"userEnteredValue": "100"}]},
+					"format": {"backgroundColor": {"red": 1, "alpha": 1}, "textFormat": {"bold": true}}
+				}
+			},
+			{
+				"ranges": [{"sheetId": 7, "startColumnIndex": 2, "endColumnIndex": 3}],
+				"gradientRule": {
+					"minpoint": {"color": {"green": 1}, "type": "MIN"},
+					"midpoint": {"color": {"blue": 1}, "type": "PERCENT", "value": "50"},
+					"maxpoint": {"color": {"red": 1}, "type": "MAX"}
+				}
+			}
+		]
+	}`), sheet)
+	assert.NoError(err)
+
+	rules := sheet.ConditionalFormats()
+	assert.Len(rules, 2)
+	assert.Equal([]GridRange{{SheetID: 7, StartColumnIndex: 1, EndColumnIndex: 2}}, rules[0].Ranges)
+	assert.Nil(rules[0].GradientRule)
+	assert.Equal(BooleanRule{
+		Condition: BooleanCondition{Type: ConditionNumberGreater, Values: []ConditionValue{{UserEnteredValue: "100"}}},
+		Format:    CellFormat{BackgroundColor: &Color{Red: 1, Alpha: 1}, TextFormat: &TextFormat{Bold: true}},
+	}, *rules[0].BooleanRule)
+	assert.Nil(rules[1].BooleanRule)
+	assert.Equal(&GradientRule{
+		Minpoint: InterpolationPoint{Color: &Color{Green: 1}, Type: InterpolationMin},
+		Midpoint: &InterpolationPoint{Color: &Color{Blue: 1}, Type: InterpolationPercent, Value: "50"},
+		Maxpoint: InterpolationPoint{Color: &Color{Red: 1}, Type: InterpolationMax},
+	}, rules[1].GradientRule)
+
+	assert.NoError(json.Unmarshal([]byte(`{"properties": {"sheetId": 8}}`), sheet))
+	assert.Empty(sheet.ConditionalFormats())
+}
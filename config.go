@@ -0,0 +1,316 @@
+package spreadsheet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ConfigLayout is how configuration is laid out in a range.
+type ConfigLayout int
+
+const (
+	// ConfigKeyValue reads keys from the first column and values from the second column.
+	ConfigKeyValue ConfigLayout = iota
+	// ConfigHeader reads keys from the first row and values from the second row.
+	ConfigHeader
+)
+
+// ConfigLoader maps configuration kept in a range of a spreadsheet into a struct.
+//
+// Struct fields are matched to keys by the "sheet" tag, or by the field name
+// ignoring case when there is no tag. Fields tagged with "-" are skipped.
+// Slices are read from comma separated values.
+type ConfigLoader struct {
+	Service       *Service
+	SpreadsheetID string
+	Range         string
+	Layout        ConfigLayout
+}
+
+// Load reads the configuration into dst, which must be a pointer to a struct.
+func (l *ConfigLoader) Load(dst interface{}) (err error) {
+	err = l.LoadContext(context.Background(), dst)
+	return
+}
+
+// LoadContext is like Load with the context of the request.
+func (l *ConfigLoader) LoadContext(ctx context.Context, dst interface{}) (err error) {
+	values, err := l.fetch(ctx)
+	if err != nil {
+		return
+	}
+	err = decodeConfig(values, l.Layout, dst)
+	return
+}
+
+func (l *ConfigLoader) fetch(ctx context.Context) (values [][]string, err error) {
+	valueRange, err := l.Service.GetValuesContext(ctx, l.SpreadsheetID, l.Range)
+	if err != nil {
+		return
+	}
+	values = toStrings(valueRange.Values)
+	return
+}
+
+// ConfigWatch configures how the configuration is watched.
+type ConfigWatch struct {
+	// Interval is the polling interval. Zero disables polling,
+	// in which case reloads are only triggered by Notify.
+	Interval time.Duration
+	// New returns a pointer to a new configuration struct to load into.
+	New func() interface{}
+	// OnReload is called with the configuration returned by New when it was
+	// loaded for the first time or when the range changed.
+	OnReload func(config interface{})
+	// OnError is called when loading fails. The previous configuration stays in effect.
+	OnError func(err error)
+}
+
+// ConfigWatcher reloads configuration when it changes in the spreadsheet.
+type ConfigWatcher struct {
+	fetch  func(context.Context) ([][]string, error)
+	layout ConfigLayout
+	watch  ConfigWatch
+	notify chan struct{}
+	done   chan struct{}
+	cancel context.CancelFunc
+
+	mu   sync.Mutex
+	last [][]string
+}
+
+// Watch loads the configuration and keeps reloading it until ctx is done or the watcher is stopped.
+func (l *ConfigLoader) Watch(ctx context.Context, watch ConfigWatch) (w *ConfigWatcher, err error) {
+	if watch.New == nil || watch.OnReload == nil {
+		err = errors.New("New and OnReload must not be nil")
+		return
+	}
+	w = newConfigWatcher(l.fetch, l.Layout, watch)
+	w.start(ctx)
+	return
+}
+
+func newConfigWatcher(fetch func(context.Context) ([][]string, error), layout ConfigLayout, watch ConfigWatch) *ConfigWatcher {
+	return &ConfigWatcher{
+		fetch:  fetch,
+		layout: layout,
+		watch:  watch,
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+func (w *ConfigWatcher) start(ctx context.Context) {
+	ctx, w.cancel = context.WithCancel(ctx)
+	go func() {
+		defer close(w.done)
+		var tick <-chan time.Time
+		if w.watch.Interval > 0 {
+			ticker := time.NewTicker(w.watch.Interval)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+		w.reload(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick:
+			case <-w.notify:
+			}
+			w.reload(ctx)
+		}
+	}()
+}
+
+// Notify triggers a reload, e.g. from a handler of a push notification.
+func (w *ConfigWatcher) Notify() {
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Stop stops watching and waits for an ongoing reload to finish.
+func (w *ConfigWatcher) Stop() {
+	w.cancel()
+	<-w.done
+}
+
+func (w *ConfigWatcher) reload(ctx context.Context) {
+	values, err := w.fetch(ctx)
+	if err != nil {
+		w.fail(err)
+		return
+	}
+	w.mu.Lock()
+	changed := w.last == nil || !reflect.DeepEqual(w.last, values)
+	w.mu.Unlock()
+	if !changed {
+		return
+	}
+	config := w.watch.New()
+	err = decodeConfig(values, w.layout, config)
+	if err != nil {
+		w.fail(err)
+		return
+	}
+	w.mu.Lock()
+	w.last = values
+	w.mu.Unlock()
+	w.watch.OnReload(config)
+}
+
+func (w *ConfigWatcher) fail(err error) {
+	if w.watch.OnError != nil {
+		w.watch.OnError(err)
+	}
+}
+
+func decodeConfig(values [][]string, layout ConfigLayout, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("config must be a non-nil pointer to a struct")
+	}
+	config := map[string]string{}
+	switch layout {
+	case ConfigKeyValue:
+		for _, row := range values {
+			if len(row) > 0 && row[0] != "" {
+				config[strings.ToLower(row[0])] = valueOrEmpty(row, 1)
+			}
+		}
+	case ConfigHeader:
+		if len(values) > 0 {
+			for i, key := range values[0] {
+				if key == "" {
+					continue
+				}
+				var row []string
+				if len(values) > 1 {
+					row = values[1]
+				}
+				config[strings.ToLower(key)] = valueOrEmpty(row, i)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown config layout: %d", layout)
+	}
+
+	v = v.Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		key := field.Tag.Get("sheet")
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = field.Name
+		}
+		s, ok := config[strings.ToLower(key)]
+		if !ok {
+			continue
+		}
+		if err := decodeValue(s, v.Field(i)); err != nil {
+			return fmt.Errorf("config %s: %v", key, err)
+		}
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// decodeValue parses the string into the value.
+func decodeValue(s string, v reflect.Value) error {
+	if c, ok := converterOf(v.Type()); ok && c.Unmarshal != nil {
+		value, err := c.Unmarshal(s)
+		if err != nil {
+			return err
+		}
+		rv := reflect.ValueOf(value)
+		if !rv.IsValid() || !rv.Type().ConvertibleTo(v.Type()) {
+			return fmt.Errorf("converter for %s returned %T", v.Type(), value)
+		}
+		v.Set(rv.Convert(v.Type()))
+		return nil
+	}
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(s))
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(s), 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Ptr:
+		if strings.TrimSpace(s) == "" {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		elem := reflect.New(v.Type().Elem())
+		if err := decodeValue(s, elem.Elem()); err != nil {
+			return err
+		}
+		v.Set(elem)
+	case reflect.Slice:
+		if strings.TrimSpace(s) == "" {
+			v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+			return nil
+		}
+		parts := strings.Split(s, ",")
+		slice := reflect.MakeSlice(v.Type(), len(parts), len(parts))
+		for i, part := range parts {
+			if err := decodeValue(strings.TrimSpace(part), slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+	default:
+		return fmt.Errorf("unsupported type: %s", v.Type())
+	}
+	return nil
+}
+
+func valueOrEmpty(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
+}
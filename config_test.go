@@ -0,0 +1,99 @@
+package spreadsheet
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfig struct {
+	Name     string
+	Enabled  bool          `sheet:"feature enabled"`
+	Workers  int           `sheet:"workers"`
+	Ratio    float64       `sheet:"ratio"`
+	Timeout  time.Duration `sheet:"timeout"`
+	Hosts    []string      `sheet:"hosts"`
+	Ignored  string        `sheet:"-"`
+	internal string
+}
+
+func TestDecodeConfigKeyValue(t *testing.T) {
+	assert := assert.New(t)
+	var config testConfig
+	err := decodeConfig([][]string{
+		{"name", "app"},
+		{"Feature Enabled", "TRUE"},
+		{"workers", " 4 "},
+		{"ratio", "0.5"},
+		{"timeout", "1m30s"},
+		{"hosts", "a.example.com, b.example.com"},
+		{"-", "x"},
+		{"unknown"},
+		{},
+	}, ConfigKeyValue, &config)
+	assert.NoError(err)
+	assert.Equal(testConfig{
+		Name:    "app",
+		Enabled: true,
+		Workers: 4,
+		Ratio:   0.5,
+		Timeout: 90 * time.Second,
+		Hosts:   []string{"a.example.com", "b.example.com"},
+	}, config)
+}
+
+func TestDecodeConfigHeader(t *testing.T) {
+	assert := assert.New(t)
+	var config testConfig
+	err := decodeConfig([][]string{
+		{"name", "workers", "hosts"},
+		{"app", "2"},
+	}, ConfigHeader, &config)
+	assert.NoError(err)
+	assert.Equal("app", config.Name)
+	assert.Equal(2, config.Workers)
+	assert.Equal([]string{}, config.Hosts)
+}
+
+func TestDecodeConfigErrors(t *testing.T) {
+	assert := assert.New(t)
+	var config testConfig
+	assert.EqualError(decodeConfig([][]string{{"workers", "many"}}, ConfigKeyValue, &config),
+		`config workers: strconv.ParseInt: parsing "many": invalid syntax`)
+	assert.Error(decodeConfig(nil, ConfigKeyValue, config))
+	assert.Error(decodeConfig(nil, ConfigLayout(9), &config))
+}
+
+func TestConfigWatcher(t *testing.T) {
+	assert := assert.New(t)
+	responses := make(chan [][]string, 3)
+	responses <- [][]string{{"name", "v1"}}
+	responses <- [][]string{{"workers", "x"}}
+	responses <- [][]string{{"name", "v2"}}
+	fetch := func(context.Context) ([][]string, error) {
+		select {
+		case values := <-responses:
+			return values, nil
+		default:
+			return nil, errors.New("no more values")
+		}
+	}
+	reloads := make(chan string, 3)
+	errs := make(chan error, 3)
+	w := newConfigWatcher(fetch, ConfigKeyValue, ConfigWatch{
+		New:      func() interface{} { return &testConfig{} },
+		OnReload: func(config interface{}) { reloads <- config.(*testConfig).Name },
+		OnError:  func(err error) { errs <- err },
+	})
+	w.start(context.Background())
+	defer w.Stop()
+
+	assert.Equal("v1", <-reloads)
+	w.Notify()
+	assert.Error(<-errs)
+	w.Notify()
+	assert.Equal("v2", <-reloads)
+}
@@ -0,0 +1,147 @@
+package spreadsheet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Converter converts values of a type to and from cell values.
+type Converter struct {
+	// Marshal returns the cell value of v, e.g. a string, a number or a bool.
+	Marshal func(v interface{}) (interface{}, error)
+	// Unmarshal parses the formatted cell value into a value of the type.
+	Unmarshal func(s string) (interface{}, error)
+}
+
+var converters = struct {
+	sync.RWMutex
+	m map[reflect.Type]Converter
+}{m: map[reflect.Type]Converter{}}
+
+// RegisterConverter registers the converter for the type of sample.
+// It is used by MarshalRow, UnmarshalRow, AppendRow and ConfigLoader
+// instead of the built-in conversion, and replaces a converter registered before.
+func RegisterConverter(sample interface{}, c Converter) {
+	converters.Lock()
+	defer converters.Unlock()
+	converters.m[reflect.TypeOf(sample)] = c
+}
+
+func converterOf(t reflect.Type) (c Converter, ok bool) {
+	converters.RLock()
+	defer converters.RUnlock()
+	c, ok = converters.m[t]
+	return
+}
+
+// MarshalRow converts the exported fields of the struct v to the values of a row,
+// in the order of the fields. Fields tagged with `sheet:"-"` are skipped.
+func MarshalRow(v interface{}) (row []interface{}, err error) {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	if rv.Kind() != reflect.Struct {
+		err = errors.New("row must be a struct or a pointer to a struct")
+		return
+	}
+	for _, i := range rowFields(rv.Type()) {
+		var value interface{}
+		value, err = encodeValue(rv.Field(i))
+		if err != nil {
+			err = fmt.Errorf("field %s: %v", rv.Type().Field(i).Name, err)
+			return
+		}
+		row = append(row, value)
+	}
+	return
+}
+
+// UnmarshalRow parses the values of a row into the exported fields of the struct
+// pointed to by dst, in the order of the fields. Fields of empty or missing cells are zeroed.
+func UnmarshalRow(row []string, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return errors.New("row must be a non-nil pointer to a struct")
+	}
+	v = v.Elem()
+	for column, i := range rowFields(v.Type()) {
+		s := valueOrEmpty(row, column)
+		if s == "" {
+			v.Field(i).Set(reflect.Zero(v.Field(i).Type()))
+			continue
+		}
+		if err := decodeValue(s, v.Field(i)); err != nil {
+			return fmt.Errorf("field %s: %v", v.Type().Field(i).Name, err)
+		}
+	}
+	return nil
+}
+
+// AppendRow appends the struct v as a row after the last row of the sheet.
+func (sheet *Sheet) AppendRow(v interface{}, opts ...CallOption) (resp AppendValuesResponse, err error) {
+	resp, err = sheet.AppendRowContext(context.Background(), v, opts...)
+	return
+}
+
+// AppendRowContext is like AppendRow with the context of the request.
+func (sheet *Sheet) AppendRowContext(ctx context.Context, v interface{}, opts ...CallOption) (resp AppendValuesResponse, err error) {
+	row, err := MarshalRow(v)
+	if err != nil {
+		return
+	}
+	resp, err = sheet.AppendValuesContext(ctx, [][]interface{}{row}, opts...)
+	return
+}
+
+// rowFields returns the indexes of the fields of the struct type mapped to columns.
+func rowFields(t reflect.Type) (fields []int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" || field.Tag.Get("sheet") == "-" {
+			continue
+		}
+		fields = append(fields, i)
+	}
+	return
+}
+
+// encodeValue converts the value to a cell value.
+func encodeValue(v reflect.Value) (interface{}, error) {
+	if c, ok := converterOf(v.Type()); ok && c.Marshal != nil {
+		return c.Marshal(v.Interface())
+	}
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String(), nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Ptr:
+		if v.IsNil() {
+			return "", nil
+		}
+		return encodeValue(v.Elem())
+	case reflect.Slice:
+		parts := make([]string, v.Len())
+		for i := range parts {
+			value, err := encodeValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			parts[i] = fmt.Sprint(value)
+		}
+		return strings.Join(parts, ","), nil
+	}
+	return nil, fmt.Errorf("unsupported type: %s", v.Type())
+}
@@ -0,0 +1,89 @@
+package spreadsheet
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLevel int
+
+type testRow struct {
+	Name    string
+	Count   int
+	Price   float64
+	Done    bool
+	Level   testLevel
+	Timeout time.Duration
+	Tags    []string
+	Note    *string
+	Skipped string `sheet:"-"`
+	hidden  string
+}
+
+func init() {
+	RegisterConverter(testLevel(0), Converter{
+		Marshal: func(v interface{}) (interface{}, error) {
+			return [...]string{"low", "high"}[v.(testLevel)], nil
+		},
+		Unmarshal: func(s string) (interface{}, error) {
+			switch strings.ToLower(s) {
+			case "low":
+				return testLevel(0), nil
+			case "high":
+				return testLevel(1), nil
+			}
+			return nil, errors.New("unknown level: " + s)
+		},
+	})
+}
+
+func TestMarshalRow(t *testing.T) {
+	assert := assert.New(t)
+	row, err := MarshalRow(&testRow{
+		Name:    "a",
+		Count:   2,
+		Price:   1.5,
+		Done:    true,
+		Level:   1,
+		Timeout: time.Minute,
+		Tags:    []string{"x", "y"},
+		Skipped: "s",
+	})
+	assert.NoError(err)
+	assert.Equal([]interface{}{"a", int64(2), 1.5, true, "high", "1m0s", "x,y", ""}, row)
+
+	_, err = MarshalRow("a")
+	assert.Error(err)
+	_, err = MarshalRow(struct{ C chan int }{})
+	assert.EqualError(err, "field C: unsupported type: chan int")
+}
+
+func TestUnmarshalRow(t *testing.T) {
+	assert := assert.New(t)
+	var row testRow
+	err := UnmarshalRow([]string{"a", "2", "1.5", "TRUE", "High", "1m", "x, y", "note"}, &row)
+	assert.NoError(err)
+	note := "note"
+	assert.Equal(testRow{
+		Name:    "a",
+		Count:   2,
+		Price:   1.5,
+		Done:    true,
+		Level:   1,
+		Timeout: time.Minute,
+		Tags:    []string{"x", "y"},
+		Note:    &note,
+	}, row)
+
+	row = testRow{}
+	assert.NoError(UnmarshalRow([]string{"b", "", "", "", "low"}, &row))
+	assert.Equal("b", row.Name)
+	assert.Nil(row.Note)
+	assert.EqualError(UnmarshalRow([]string{"b", "", "", "", "medium"}, &row), "field Level: unknown level: medium")
+	assert.EqualError(UnmarshalRow([]string{"b", "two"}, &row), `field Count: strconv.ParseInt: parsing "two": invalid syntax`)
+	assert.Error(UnmarshalRow(nil, row))
+}
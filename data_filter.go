@@ -0,0 +1,42 @@
+package spreadsheet
+
+// DataFilter selects data of a spreadsheet. Exactly one of the fields must be set.
+type DataFilter struct {
+	DeveloperMetadataLookup *DeveloperMetadataLookup `json:"developerMetadataLookup,omitempty"`
+	A1Range                 string                   `json:"a1Range,omitempty"`
+	GridRange               *GridRange               `json:"gridRange,omitempty"`
+}
+
+// MatchedValueRange is a value range matched by data filters.
+type MatchedValueRange struct {
+	ValueRange  ValueRange   `json:"valueRange"`
+	DataFilters []DataFilter `json:"dataFilters"`
+}
+
+// DataFilterValueRange is values to write to the range matched by a data filter.
+type DataFilterValueRange struct {
+	DataFilter     DataFilter      `json:"dataFilter"`
+	MajorDimension Dimension       `json:"majorDimension,omitempty"`
+	Values         [][]interface{} `json:"values"`
+}
+
+// UpdateValuesByDataFilterResponse is the result of writing to the range matched by a data filter.
+type UpdateValuesByDataFilterResponse struct {
+	UpdatedRange   string     `json:"updatedRange"`
+	UpdatedRows    int        `json:"updatedRows"`
+	UpdatedColumns int        `json:"updatedColumns"`
+	UpdatedCells   int        `json:"updatedCells"`
+	DataFilter     DataFilter `json:"dataFilter"`
+	// UpdatedData is the values stored by the server, when requested by WithValuesInResponse.
+	UpdatedData *ValueRange `json:"updatedData,omitempty"`
+}
+
+// BatchUpdateValuesByDataFilterResponse is the response when writing to ranges matched by data filters.
+type BatchUpdateValuesByDataFilterResponse struct {
+	SpreadsheetID       string                             `json:"spreadsheetId"`
+	TotalUpdatedRows    int                                `json:"totalUpdatedRows"`
+	TotalUpdatedColumns int                                `json:"totalUpdatedColumns"`
+	TotalUpdatedCells   int                                `json:"totalUpdatedCells"`
+	TotalUpdatedSheets  int                                `json:"totalUpdatedSheets"`
+	Responses           []UpdateValuesByDataFilterResponse `json:"responses"`
+}
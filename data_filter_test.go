@@ -0,0 +1,21 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataFilterJSON(t *testing.T) {
+	assert := assert.New(t)
+	b, err := json.Marshal([]DataFilter{
+		{GridRange: &GridRange{SheetID: 0, StartRowIndex: 1, EndRowIndex: 3}},
+		{DeveloperMetadataLookup: &DeveloperMetadataLookup{MetadataKey: "id", MetadataValue: "42"}},
+	})
+	assert.NoError(err)
+	assert.JSONEq(`[
+		{"gridRange": {"sheetId": 0, "startRowIndex": 1, "endRowIndex": 3}},
+		{"developerMetadataLookup": {"metadataKey": "id", "metadataValue": "42"}}
+	]`, string(b))
+}
@@ -0,0 +1,97 @@
+package spreadsheet
+
+import (
+	"context"
+	"errors"
+)
+
+// DataValidationRule is a rule of data validation of cells.
+type DataValidationRule struct {
+	Condition BooleanCondition `json:"condition"`
+	// InputMessage is shown when the cells are selected.
+	InputMessage string `json:"inputMessage,omitempty"`
+	// Strict rejects invalid data rather than showing a warning.
+	Strict bool `json:"strict,omitempty"`
+	// ShowCustomUI shows the UI of the condition in the cells, e.g. a dropdown.
+	ShowCustomUI bool `json:"showCustomUi,omitempty"`
+}
+
+// BooleanCondition is a condition which cells meet or not.
+type BooleanCondition struct {
+	Type   ConditionType    `json:"type"`
+	Values []ConditionValue `json:"values,omitempty"`
+}
+
+// ConditionValue is a value of a condition.
+type ConditionValue struct {
+	// UserEnteredValue is parsed as entered in a cell, e.g. "=A1" is a formula.
+	UserEnteredValue string `json:"userEnteredValue,omitempty"`
+}
+
+// SetCheckboxes turns the cells of the range of the sheet into checkboxes, which are checked when the cells are
+// TRUE, e.g. set by Sheet.UpdateBool, and unchecked otherwise. The sheet ID of the range is the one of the sheet.
+func (s *Service) SetCheckboxes(sheet *Sheet, gridRange GridRange) (err error) {
+	err = s.SetCheckboxesContext(context.Background(), sheet, gridRange)
+	return
+}
+
+// SetCheckboxesContext is like SetCheckboxes with the context of the request.
+func (s *Service) SetCheckboxesContext(ctx context.Context, sheet *Sheet, gridRange GridRange) (err error) {
+	err = s.SetDataValidationContext(ctx, sheet, gridRange, &DataValidationRule{
+		Condition: BooleanCondition{Type: ConditionBoolean},
+	})
+	return
+}
+
+// SetDropdown shows a dropdown of the values in the cells of the range of the sheet, e.g. a status column,
+// and warns about other values. The sheet ID of the range is the one of the sheet.
+func (s *Service) SetDropdown(sheet *Sheet, gridRange GridRange, values []string) (err error) {
+	err = s.SetDropdownContext(context.Background(), sheet, gridRange, values)
+	return
+}
+
+// SetDropdownContext is like SetDropdown with the context of the request.
+func (s *Service) SetDropdownContext(ctx context.Context, sheet *Sheet, gridRange GridRange, values []string) (err error) {
+	if len(values) == 0 {
+		err = errors.New("values must not be empty")
+		return
+	}
+	condition := BooleanCondition{Type: ConditionOneOfList}
+	for _, v := range values {
+		condition.Values = append(condition.Values, ConditionValue{UserEnteredValue: v})
+	}
+	err = s.SetDataValidationContext(ctx, sheet, gridRange, &DataValidationRule{Condition: condition, ShowCustomUI: true})
+	return
+}
+
+// SetDropdownFromRange shows a dropdown of the values in the A1 range, e.g. "Statuses!A1:A5", in the cells
+// of the range of the sheet, and warns about other values. The sheet ID of the range is the one of the sheet.
+func (s *Service) SetDropdownFromRange(sheet *Sheet, gridRange GridRange, a1Range string) (err error) {
+	err = s.SetDropdownFromRangeContext(context.Background(), sheet, gridRange, a1Range)
+	return
+}
+
+// SetDropdownFromRangeContext is like SetDropdownFromRange with the context of the request.
+func (s *Service) SetDropdownFromRangeContext(ctx context.Context, sheet *Sheet, gridRange GridRange, a1Range string) (err error) {
+	condition := BooleanCondition{Type: ConditionOneOfRange, Values: []ConditionValue{{UserEnteredValue: "=" + a1Range}}}
+	err = s.SetDataValidationContext(ctx, sheet, gridRange, &DataValidationRule{Condition: condition, ShowCustomUI: true})
+	return
+}
+
+// SetDataValidation sets the rule of data validation of the cells of the range of the sheet.
+// A nil rule removes the data validation. The sheet ID of the range is the one of the sheet.
+func (s *Service) SetDataValidation(sheet *Sheet, gridRange GridRange, rule *DataValidationRule) (err error) {
+	err = s.SetDataValidationContext(context.Background(), sheet, gridRange, rule)
+	return
+}
+
+// SetDataValidationContext is like SetDataValidation with the context of the request.
+func (s *Service) SetDataValidationContext(ctx context.Context, sheet *Sheet, gridRange GridRange, rule *DataValidationRule) (err error) {
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	gridRange.SheetID = sheet.Properties.ID
+	_, err = r.SetDataValidation(gridRange, rule).Do(ctx)
+	return
+}
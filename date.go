@@ -0,0 +1,88 @@
+package spreadsheet
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// serialEpoch is the day 0 of the serial numbers of dates.
+var serialEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// SerialToTime returns the time of the serial number of a date and time in the location, e.g. 43466.5
+// is 2019-01-01 12:00 there. The serial number is the number of days since 1899-12-30, with the time of
+// the day as the fraction, as Sheets stores dates. It's rounded to the millisecond.
+func SerialToTime(serial float64, loc *time.Location) time.Time {
+	days := math.Floor(serial)
+	ms := int(math.Round((serial - days) * 24 * 60 * 60 * 1000))
+	// the nanoseconds of a day overflow an int on 32-bit platforms, the milliseconds don't
+	return time.Date(1899, 12, 30+int(days), 0, 0, ms/1000, ms%1000*int(time.Millisecond), loc)
+}
+
+// TimeToSerial returns the serial number of the date and time of t in the location, see SerialToTime.
+func TimeToSerial(t time.Time, loc *time.Location) float64 {
+	t = t.In(loc)
+	year, month, day := t.Date()
+	days := (time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() - serialEpoch.Unix()) / (24 * 60 * 60)
+	hour, min, sec := t.Clock()
+	seconds := float64(hour*60*60+min*60+sec) + float64(t.Nanosecond())/float64(time.Second)
+	return float64(days) + seconds/(24*60*60)
+}
+
+// Location returns the location of the time zone of the spreadsheet, or UTC when it has none.
+func (spreadsheet *Spreadsheet) Location() (*time.Location, error) {
+	if spreadsheet.Properties.TimeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(spreadsheet.Properties.TimeZone)
+}
+
+// location returns the location of the spreadsheet of the sheet, or UTC when it has none.
+func (sheet *Sheet) location() (*time.Location, error) {
+	if sheet.Spreadsheet == nil {
+		return time.UTC, nil
+	}
+	return sheet.Spreadsheet.Location()
+}
+
+// Time returns the date and time entered in the cell, from its serial number in the location,
+// if the cell holds a number.
+func (cell *Cell) Time(loc *time.Location) (t time.Time, ok bool) {
+	serial, ok := cell.NumberValue()
+	if !ok && cell.EffectiveValue != nil && cell.EffectiveValue.IsNumber() {
+		serial, ok = cell.EffectiveValue.NumberValue, true
+	}
+	if ok {
+		t = SerialToTime(serial, loc)
+	}
+	return
+}
+
+// Time returns the date and time of the cell at the row and the column in the time zone of the spreadsheet.
+// The cell must hold a number, e.g. a date entered in the UI or by UpdateTime.
+func (sheet *Sheet) Time(row, column int) (t time.Time, err error) {
+	loc, err := sheet.location()
+	if err != nil {
+		return
+	}
+	cell, err := sheet.Cell(row, column)
+	if err != nil {
+		return
+	}
+	t, ok := cell.Time(loc)
+	if !ok {
+		err = errors.New("cell " + cell.Pos() + " doesn't hold a date")
+	}
+	return
+}
+
+// UpdateTime updates the cell with the serial number of the date and time in the time zone of the spreadsheet,
+// like UpdateNumber. The cell needs a date or time number format to display it as a date.
+func (sheet *Sheet) UpdateTime(row, column int, t time.Time) (err error) {
+	loc, err := sheet.location()
+	if err != nil {
+		return
+	}
+	err = sheet.UpdateNumber(row, column, TimeToSerial(t, loc))
+	return
+}
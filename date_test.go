@@ -0,0 +1,35 @@
+package spreadsheet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerialTime(t *testing.T) {
+	assert := assert.New(t)
+	tokyo := time.FixedZone("JST", 9*60*60)
+	assert.Equal(time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC), SerialToTime(43466.5, time.UTC))
+	assert.Equal(time.Date(1899, 12, 30, 0, 0, 0, 0, tokyo), SerialToTime(0, tokyo))
+	assert.Equal(time.Date(1899, 12, 29, 6, 0, 0, 0, time.UTC), SerialToTime(-0.75, time.UTC))
+	assert.Equal(time.Date(2024, 2, 29, 6, 30, 15, 0, tokyo), SerialToTime(TimeToSerial(time.Date(2024, 2, 29, 6, 30, 15, 0, tokyo), tokyo), tokyo))
+	assert.Equal(43466.5, TimeToSerial(time.Date(2019, 1, 1, 21, 0, 0, 0, tokyo), time.UTC))
+	assert.Equal(43466.875, TimeToSerial(time.Date(2019, 1, 1, 21, 0, 0, 0, tokyo), tokyo))
+
+	spreadsheet := &Spreadsheet{Properties: Properties{TimeZone: "Asia/Tokyo"}, Sheets: []Sheet{{}}}
+	sheet := &spreadsheet.Sheets[0]
+	sheet.Spreadsheet = spreadsheet
+	if _, err := spreadsheet.Location(); err != nil {
+		t.Skip("no time zone database:", err)
+	}
+	assert.NoError(sheet.UpdateTime(0, 0, time.Date(2019, 1, 1, 3, 0, 0, 0, time.UTC)))
+	serial, _ := sheet.Rows[0][0].NumberValue()
+	assert.Equal(43466.5, serial)
+	got, err := sheet.Time(0, 0)
+	assert.NoError(err)
+	assert.True(got.Equal(time.Date(2019, 1, 1, 3, 0, 0, 0, time.UTC)))
+	sheet.Update(0, 1, "text")
+	_, err = sheet.Time(0, 1)
+	assert.Error(err)
+}
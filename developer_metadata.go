@@ -0,0 +1,90 @@
+package spreadsheet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// DeveloperMetadata is metadata associated with a location of a spreadsheet.
+type DeveloperMetadata struct {
+	MetadataID    int                        `json:"metadataId,omitempty"`
+	MetadataKey   string                     `json:"metadataKey,omitempty"`
+	MetadataValue string                     `json:"metadataValue,omitempty"`
+	Location      *DeveloperMetadataLocation `json:"location,omitempty"`
+	Visibility    string                     `json:"visibility,omitempty"`
+}
+
+// DeveloperMetadataLocation is a location where metadata may be associated.
+type DeveloperMetadataLocation struct {
+	LocationType   string          `json:"locationType,omitempty"`
+	Spreadsheet    bool            `json:"spreadsheet,omitempty"`
+	SheetID        *uint           `json:"sheetId,omitempty"`
+	DimensionRange *DimensionRange `json:"dimensionRange,omitempty"`
+}
+
+// DeveloperMetadataLookup selects developer metadata. Unset fields match any metadata.
+type DeveloperMetadataLookup struct {
+	LocationType             string                     `json:"locationType,omitempty"`
+	MetadataLocation         *DeveloperMetadataLocation `json:"metadataLocation,omitempty"`
+	LocationMatchingStrategy string                     `json:"locationMatchingStrategy,omitempty"`
+	MetadataID               int                        `json:"metadataId,omitempty"`
+	MetadataKey              string                     `json:"metadataKey,omitempty"`
+	MetadataValue            string                     `json:"metadataValue,omitempty"`
+	Visibility               string                     `json:"visibility,omitempty"`
+}
+
+// GetDeveloperMetadata fetches the developer metadata by its ID.
+func (s *Service) GetDeveloperMetadata(spreadsheetID string, metadataID int) (metadata DeveloperMetadata, err error) {
+	metadata, err = s.GetDeveloperMetadataContext(context.Background(), spreadsheetID, metadataID)
+	return
+}
+
+// GetDeveloperMetadataContext is like GetDeveloperMetadata with the context of the request.
+func (s *Service) GetDeveloperMetadataContext(ctx context.Context, spreadsheetID string, metadataID int) (metadata DeveloperMetadata, err error) {
+	path := fmt.Sprintf("/spreadsheets/%s/developerMetadata/%d", spreadsheetID, metadataID)
+	body, err := s.get(ctx, path)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &metadata)
+	return
+}
+
+// MatchedDeveloperMetadata is developer metadata matched by data filters.
+type MatchedDeveloperMetadata struct {
+	DeveloperMetadata DeveloperMetadata `json:"developerMetadata"`
+	DataFilters       []DataFilter      `json:"dataFilters"`
+}
+
+// SearchDeveloperMetadata fetches the developer metadata matching any of the data filters,
+// e.g. to find the row tagged with an external ID. All matches are returned at once, without pages.
+func (s *Service) SearchDeveloperMetadata(spreadsheetID string, filters ...DataFilter) (matched []MatchedDeveloperMetadata, err error) {
+	matched, err = s.SearchDeveloperMetadataContext(context.Background(), spreadsheetID, filters...)
+	return
+}
+
+// SearchDeveloperMetadataContext is like SearchDeveloperMetadata with the context of the request.
+func (s *Service) SearchDeveloperMetadataContext(ctx context.Context, spreadsheetID string, filters ...DataFilter) (matched []MatchedDeveloperMetadata, err error) {
+	if len(filters) == 0 {
+		err = errors.New("filters must not be empty")
+		return
+	}
+	path := fmt.Sprintf("/spreadsheets/%s/developerMetadata:search", spreadsheetID)
+	body, err := s.post(ctx, path, map[string]interface{}{
+		"dataFilters": filters,
+	})
+	if err != nil {
+		return
+	}
+	var resp struct {
+		MatchedDeveloperMetadata []MatchedDeveloperMetadata `json:"matchedDeveloperMetadata"`
+	}
+	err = json.Unmarshal([]byte(body), &resp)
+	if err != nil {
+		return
+	}
+	matched = resp.MatchedDeveloperMetadata
+	return
+}
@@ -0,0 +1,143 @@
+package spreadsheet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// deviceCodeURL is the device authorization endpoint of Google.
+const deviceCodeURL = "https://oauth2.googleapis.com/device/code"
+
+// devicePollInterval is the wait between the polls of the token endpoint when the device endpoint gives none.
+var devicePollInterval = 5 * time.Second
+
+// WithDeviceFlow makes NewServiceForCLI authorize the user by the OAuth device flow, for machines
+// without a browser: the user opens the printed URL on another device and enters the printed code.
+// The OAuth client of the secret file must be of the "TVs and Limited Input devices" type.
+func WithDeviceFlow() ServiceOption {
+	return func(s *serviceSettings) {
+		s.authFlow = func(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error) {
+			return deviceFlow(ctx, config, deviceCodeURL)
+		}
+	}
+}
+
+type deviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURL string `json:"verification_url"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+type deviceToken struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Error        string `json:"error"`
+}
+
+// deviceFlow asks the device endpoint for a user code, prints it and polls the token endpoint
+// until the user has authorized the CLI.
+func deviceFlow(ctx context.Context, config *oauth2.Config, deviceURL string) (tok *oauth2.Token, err error) {
+	var code deviceCode
+	err = postForm(ctx, deviceURL, url.Values{
+		"client_id": {config.ClientID},
+		"scope":     {strings.Join(config.Scopes, " ")},
+	}, &code)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to get device code: %v", err)
+	}
+	fmt.Printf("Go to the following link on any device then enter the code %s: \n%v\n", code.UserCode, code.VerificationURL)
+
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = devicePollInterval
+	}
+	var expired <-chan time.Time
+	if code.ExpiresIn > 0 {
+		expired = time.After(time.Duration(code.ExpiresIn) * time.Second)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-expired:
+			return nil, errors.New("Unable to retrieve token from web: the device code expired")
+		case <-time.After(interval):
+		}
+		var resp deviceToken
+		err = postForm(ctx, config.Endpoint.TokenURL, url.Values{
+			"client_id":     {config.ClientID},
+			"client_secret": {config.ClientSecret},
+			"device_code":   {code.DeviceCode},
+			"grant_type":    {"urn:ietf:params:oauth:grant-type:device_code"},
+		}, &resp)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		switch resp.Error {
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += 5 * time.Second
+			continue
+		case "":
+		default:
+			return nil, fmt.Errorf("Unable to retrieve token from web: %s", resp.Error)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Unable to retrieve token from web: %v", err)
+		}
+		tok = &oauth2.Token{
+			AccessToken:  resp.AccessToken,
+			TokenType:    resp.TokenType,
+			RefreshToken: resp.RefreshToken,
+		}
+		if resp.ExpiresIn > 0 {
+			tok.Expiry = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+		}
+		return
+	}
+}
+
+// postForm posts the form to the URL with the HTTP client of the context, if any, and decodes the JSON response.
+// The response is decoded even when the status isn't 2xx, with an error.
+func postForm(ctx context.Context, rawURL string, form url.Values, v interface{}) (err error) {
+	client := http.DefaultClient
+	if c, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok {
+		client = c
+	}
+	req, err := http.NewRequest(http.MethodPost, rawURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return
+	}
+	if jsonErr := json.Unmarshal(body, v); jsonErr != nil {
+		err = fmt.Errorf("%s: %v", resp.Status, jsonErr)
+		return
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		err = fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return
+}
@@ -0,0 +1,63 @@
+package spreadsheet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestDeviceFlow(t *testing.T) {
+	assert := assert.New(t)
+	interval := devicePollInterval
+	devicePollInterval = time.Millisecond
+	defer func() {
+		devicePollInterval = interval
+	}()
+
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/device/code":
+			assert.Equal("client", r.PostForm.Get("client_id"))
+			assert.Equal(SpreadsheetsScope, r.PostForm.Get("scope"))
+			w.Write([]byte(`{"device_code":"device","user_code":"ABCD-EFGH","verification_url":"https://www.google.com/device","expires_in":60}`))
+		case "/token":
+			assert.Equal("device", r.PostForm.Get("device_code"))
+			polls++
+			if polls < 3 {
+				w.WriteHeader(http.StatusPreconditionRequired)
+				w.Write([]byte(`{"error":"authorization_pending"}`))
+				return
+			}
+			w.Write([]byte(`{"access_token":"access","token_type":"Bearer","refresh_token":"refresh","expires_in":3600}`))
+		}
+	}))
+	defer server.Close()
+
+	config := &oauth2.Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Endpoint:     oauth2.Endpoint{TokenURL: server.URL + "/token"},
+		Scopes:       []string{SpreadsheetsScope},
+	}
+	tok, err := deviceFlow(context.Background(), config, server.URL+"/device/code")
+	if assert.NoError(err) {
+		assert.Equal("access", tok.AccessToken)
+		assert.Equal("refresh", tok.RefreshToken)
+		assert.True(tok.Expiry.After(time.Now()))
+	}
+	assert.Equal(3, polls)
+
+	polls = -10
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err = deviceFlow(ctx, config, server.URL+"/device/code")
+	assert.Equal(context.DeadlineExceeded, err)
+}
@@ -7,3 +7,27 @@ type DimensionProperties struct {
 	PixelSize      uint `json:"pixelSize"`
 	// DeveloperMetadata []*DeveloperMetadata `json:"developerMetadata"`
 }
+
+// RowMetadata returns the properties of the zero based row, e.g. its height in pixels,
+// if they were fetched with its cells.
+func (sheet *Sheet) RowMetadata(row int) (properties DimensionProperties, ok bool) {
+	for _, gridData := range sheet.Data.GridData {
+		if i := row - int(gridData.StartRow); i >= 0 && i < len(gridData.RowMetadata) && gridData.RowMetadata[i] != nil {
+			properties, ok = *gridData.RowMetadata[i], true
+			return
+		}
+	}
+	return
+}
+
+// ColumnMetadata returns the properties of the zero based column, e.g. its width in pixels,
+// if they were fetched with its cells.
+func (sheet *Sheet) ColumnMetadata(column int) (properties DimensionProperties, ok bool) {
+	for _, gridData := range sheet.Data.GridData {
+		if i := column - int(gridData.StartColumn); i >= 0 && i < len(gridData.ColumnMetadata) && gridData.ColumnMetadata[i] != nil {
+			properties, ok = *gridData.ColumnMetadata[i], true
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,49 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRowAndColumnMetadata(t *testing.T) {
+	assert := assert.New(t)
+	sheet := &Sheet{}
+	err := json.Unmarshal([]byte(`{
+		"properties": {"gridProperties": {"rowCount": 10, "columnCount": 3}},
+		"data": [
+			{
+				"rowData": [{"values": [{"formattedValue": "a"}]}],
+				"rowMetadata": [{"pixelSize": 40}, {"pixelSize": 21, "hiddenByUser": true}],
+				"columnMetadata": [{"pixelSize": 100}, {"pixelSize": 200}, {"pixelSize": 50, "hiddenByFilter": true}]
+			},
+			{"startRow": 5, "rowMetadata": [{"pixelSize": 30}]}
+		]
+	}`), sheet)
+	assert.NoError(err)
+
+	properties, ok := sheet.RowMetadata(0)
+	assert.True(ok)
+	assert.Equal(DimensionProperties{PixelSize: 40}, properties)
+	properties, ok = sheet.RowMetadata(1)
+	assert.True(ok)
+	assert.True(properties.HiddenByUser)
+	properties, ok = sheet.RowMetadata(5)
+	assert.True(ok)
+	assert.Equal(uint(30), properties.PixelSize)
+	_, ok = sheet.RowMetadata(3)
+	assert.False(ok)
+	_, ok = sheet.RowMetadata(-1)
+	assert.False(ok)
+
+	properties, ok = sheet.ColumnMetadata(2)
+	assert.True(ok)
+	assert.Equal(DimensionProperties{PixelSize: 50, HiddenByFilter: true}, properties)
+	_, ok = sheet.ColumnMetadata(3)
+	assert.False(ok)
+
+	fields := NewServiceWithClient(nil).spreadsheetFields(nil)
+	assert.Contains(fields, "rowMetadata(pixelSize,hiddenByUser,hiddenByFilter)")
+	assert.Contains(fields, "columnMetadata(pixelSize,hiddenByUser,hiddenByFilter)")
+}
@@ -0,0 +1,97 @@
+package spreadsheet
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+// SpreadsheetFile is a spreadsheet listed from Google Drive.
+type SpreadsheetFile struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	ModifiedTime string `json:"modifiedTime"`
+}
+
+// Cursor is an opaque position in a paginated listing. It can be persisted,
+// e.g. as a checkpoint of a long crawl, and passed again to resume the listing
+// from the same page, also from another process. The empty cursor is the first page.
+type Cursor string
+
+type cursor struct {
+	Query     string `json:"q"`
+	PageToken string `json:"t"`
+}
+
+func (c Cursor) decode() (decoded cursor, err error) {
+	if c == "" {
+		return
+	}
+	data, err := base64.RawURLEncoding.DecodeString(string(c))
+	if err != nil {
+		err = errors.New("invalid cursor")
+		return
+	}
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		err = errors.New("invalid cursor")
+	}
+	return
+}
+
+func (c cursor) encode() Cursor {
+	if c.PageToken == "" {
+		return ""
+	}
+	data, _ := json.Marshal(c)
+	return Cursor(base64.RawURLEncoding.EncodeToString(data))
+}
+
+const spreadsheetQuery = "mimeType='application/vnd.google-apps.spreadsheet' and trashed=false"
+
+// ListSpreadsheets lists a page of up to pageSize spreadsheets accessible with the credentials
+// of the service, starting at the cursor. The returned cursor is empty after the last page.
+// Listing requires one of the Drive scopes. The same cursor can be requested again, e.g. after an error.
+func (s *Service) ListSpreadsheets(c Cursor, pageSize int) (files []SpreadsheetFile, next Cursor, err error) {
+	files, next, err = s.ListSpreadsheetsContext(context.Background(), c, pageSize)
+	return
+}
+
+// ListSpreadsheetsContext is like ListSpreadsheets with the context of the request.
+func (s *Service) ListSpreadsheetsContext(ctx context.Context, c Cursor, pageSize int) (files []SpreadsheetFile, next Cursor, err error) {
+	decoded, err := c.decode()
+	if err != nil {
+		return
+	}
+	if decoded.Query == "" {
+		decoded.Query = spreadsheetQuery
+	}
+	params := url.Values{
+		"q":       {decoded.Query},
+		"orderBy": {"name"},
+		"fields":  {"nextPageToken,files(id,name,modifiedTime)"},
+	}
+	if pageSize > 0 {
+		params.Set("pageSize", strconv.Itoa(pageSize))
+	}
+	if decoded.PageToken != "" {
+		params.Set("pageToken", decoded.PageToken)
+	}
+	body, err := s.getURL(ctx, driveBaseURL+"/files?"+params.Encode())
+	if err != nil {
+		return
+	}
+	var resp struct {
+		NextPageToken string            `json:"nextPageToken"`
+		Files         []SpreadsheetFile `json:"files"`
+	}
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return
+	}
+	files = resp.Files
+	next = cursor{Query: decoded.Query, PageToken: resp.NextPageToken}.encode()
+	return
+}
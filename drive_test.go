@@ -0,0 +1,24 @@
+package spreadsheet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	assert := assert.New(t)
+	c := cursor{Query: spreadsheetQuery, PageToken: "token"}.encode()
+	assert.NotEmpty(c)
+	decoded, err := c.decode()
+	assert.NoError(err)
+	assert.Equal(cursor{Query: spreadsheetQuery, PageToken: "token"}, decoded)
+
+	assert.Equal(Cursor(""), cursor{Query: spreadsheetQuery}.encode())
+	decoded, err = Cursor("").decode()
+	assert.NoError(err)
+	assert.Equal(cursor{}, decoded)
+
+	_, err = Cursor("not a cursor!").decode()
+	assert.EqualError(err, "invalid cursor")
+}
@@ -0,0 +1,92 @@
+package spreadsheet
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// DryRunRequest is a mutation recorded instead of sent by a service in dry-run mode.
+type DryRunRequest struct {
+	Method string
+	URL    string
+	// Body is the JSON body of the request.
+	Body []byte
+}
+
+// WithDryRun makes the service record the mutations instead of sending them, e.g. to preview the changes.
+// The mutations succeed with an empty response, so the spreadsheets aren't reloaded, while the reads
+// are still sent. The recorded requests are returned by DryRunRequests and logged by WithLogger.
+func WithDryRun() ServiceOption {
+	return func(s *serviceSettings) {
+		s.dryRun.enabled = true
+	}
+}
+
+// DryRunRequests returns the mutations recorded in dry-run mode, in the order of the calls.
+func (s *Service) DryRunRequests() []DryRunRequest {
+	return s.dryRun.snapshot()
+}
+
+// ResetDryRunRequests forgets the mutations recorded in dry-run mode.
+func (s *Service) ResetDryRunRequests() {
+	s.dryRun.reset()
+}
+
+// readEndpoints are the custom methods sent by POST which only read.
+var readEndpoints = []string{":getByDataFilter", ":batchGetByDataFilter", ":search"}
+
+// isMutation reports whether the request of the URL changes the spreadsheets.
+func isMutation(method string, u *url.URL) bool {
+	if method == http.MethodGet {
+		return false
+	}
+	path := u.Path
+	for _, suffix := range readEndpoints {
+		if strings.HasSuffix(path, suffix) {
+			return false
+		}
+	}
+	return true
+}
+
+type dryRunRecorder struct {
+	enabled  bool
+	mu       sync.Mutex
+	requests []DryRunRequest
+}
+
+func (r *dryRunRecorder) record(method, rawURL string, body []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, DryRunRequest{Method: method, URL: rawURL, Body: body})
+}
+
+func (r *dryRunRecorder) snapshot() []DryRunRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]DryRunRequest(nil), r.requests...)
+}
+
+func (r *dryRunRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = nil
+}
+
+// skipDryRun records the request instead of sending it if it's a mutation in dry-run mode.
+func (s *Service) skipDryRun(method, rawURL string, reqBody []byte) bool {
+	if !s.dryRun.enabled {
+		return false
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || !isMutation(method, u) {
+		return false
+	}
+	s.dryRun.record(method, rawURL, reqBody)
+	if s.logger != nil {
+		s.logger.Printf("%s %s dry run\nrequest: %s", method, u.RequestURI(), reqBody)
+	}
+	return true
+}
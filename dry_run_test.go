@@ -0,0 +1,65 @@
+package spreadsheet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDryRun(t *testing.T) {
+	assert := assert.New(t)
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values":[["a"]],"valueRanges":[]}`))
+	}))
+	defer server.Close()
+
+	logger := &testLogger{}
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL), WithDryRun(), WithLogger(logger))
+	resp, err := s.UpdateValues("abc", "A1", [][]interface{}{{1}})
+	assert.NoError(err)
+	assert.Equal(UpdateValuesResponse{}, resp)
+	_, err = s.ClearValues("abc", "A1")
+	assert.NoError(err)
+	valueRange, err := s.GetValues("abc", "A1")
+	assert.NoError(err)
+	assert.Equal([][]interface{}{{"a"}}, valueRange.Values)
+	_, err = s.BatchGetValuesByDataFilter("abc", DataFilter{A1Range: "A1"})
+	assert.NoError(err)
+
+	assert.Equal([]string{"GET /spreadsheets/abc/values/A1", "POST /spreadsheets/abc/values:batchGetByDataFilter"}, sent)
+	requests := s.DryRunRequests()
+	if assert.Len(requests, 2) {
+		assert.Equal(http.MethodPut, requests[0].Method)
+		assert.Equal(server.URL+"/spreadsheets/abc/values/A1?valueInputOption=USER_ENTERED", requests[0].URL)
+		assert.JSONEq(`{"majorDimension":"ROWS","range":"A1","values":[[1]]}`, string(requests[0].Body))
+		assert.Equal(server.URL+"/spreadsheets/abc/values/A1:clear", requests[1].URL)
+	}
+	if assert.Len(logger.lines, 4) {
+		assert.Equal("PUT /spreadsheets/abc/values/A1?valueInputOption=USER_ENTERED dry run\n"+
+			`request: {"majorDimension":"ROWS","range":"A1","values":[[1]]}`, logger.lines[0])
+	}
+
+	s.ResetDryRunRequests()
+	assert.Empty(s.DryRunRequests())
+}
+
+func TestDryRunAddSheet(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+	}))
+	defer server.Close()
+
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL), WithDryRun())
+	spreadsheet := &Spreadsheet{ID: "abc", service: s, Sheets: []Sheet{{Properties: SheetProperties{ID: 1, Title: "Sheet1"}}}}
+	assert.NoError(s.AddSheet(spreadsheet, SheetProperties{Title: "New"}))
+	reply, err := s.AddSheetReply(spreadsheet, SheetProperties{Title: "New"})
+	assert.NoError(err)
+	assert.Equal(AddSheetResponse{}, reply)
+	assert.Len(s.DryRunRequests(), 2)
+}
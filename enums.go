@@ -0,0 +1,313 @@
+package spreadsheet
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MergeType is how the cells of a range are merged.
+type MergeType string
+
+// Merge types.
+const (
+	// MergeAll merges all cells of the range into one cell.
+	MergeAll MergeType = "MERGE_ALL"
+	// MergeColumns merges the cells of each column of the range.
+	MergeColumns MergeType = "MERGE_COLUMNS"
+	// MergeRows merges the cells of each row of the range.
+	MergeRows MergeType = "MERGE_ROWS"
+)
+
+// PasteType is what kind of data is pasted.
+type PasteType string
+
+// Paste types.
+const (
+	PasteNormal                PasteType = "PASTE_NORMAL"
+	PasteValues                PasteType = "PASTE_VALUES"
+	PasteFormat                PasteType = "PASTE_FORMAT"
+	PasteNoBorders             PasteType = "PASTE_NO_BORDERS"
+	PasteFormula               PasteType = "PASTE_FORMULA"
+	PasteDataValidation        PasteType = "PASTE_DATA_VALIDATION"
+	PasteConditionalFormatting PasteType = "PASTE_CONDITIONAL_FORMATTING"
+)
+
+// ChartType is the type of a basic chart.
+type ChartType string
+
+// Basic chart types.
+const (
+	ChartBar         ChartType = "BAR"
+	ChartLine        ChartType = "LINE"
+	ChartArea        ChartType = "AREA"
+	ChartColumn      ChartType = "COLUMN"
+	ChartScatter     ChartType = "SCATTER"
+	ChartCombo       ChartType = "COMBO"
+	ChartSteppedArea ChartType = "STEPPED_AREA"
+)
+
+// LegendPosition is where the legend of a chart is positioned.
+type LegendPosition string
+
+// Legend positions.
+const (
+	LegendBottom LegendPosition = "BOTTOM_LEGEND"
+	LegendLeft   LegendPosition = "LEFT_LEGEND"
+	LegendRight  LegendPosition = "RIGHT_LEGEND"
+	LegendTop    LegendPosition = "TOP_LEGEND"
+	LegendNone   LegendPosition = "NO_LEGEND"
+)
+
+// AxisPosition is the position of an axis of a chart.
+type AxisPosition string
+
+// Axis positions.
+const (
+	AxisBottom AxisPosition = "BOTTOM_AXIS"
+	AxisLeft   AxisPosition = "LEFT_AXIS"
+	AxisRight  AxisPosition = "RIGHT_AXIS"
+)
+
+// NumberFormatType is the type of a number format.
+type NumberFormatType string
+
+// Number format types.
+const (
+	NumberFormatText       NumberFormatType = "TEXT"
+	NumberFormatNumber     NumberFormatType = "NUMBER"
+	NumberFormatPercent    NumberFormatType = "PERCENT"
+	NumberFormatCurrency   NumberFormatType = "CURRENCY"
+	NumberFormatDate       NumberFormatType = "DATE"
+	NumberFormatTime       NumberFormatType = "TIME"
+	NumberFormatDateTime   NumberFormatType = "DATE_TIME"
+	NumberFormatScientific NumberFormatType = "SCIENTIFIC"
+)
+
+// HorizontalAlignment is the horizontal alignment of the text of a cell.
+type HorizontalAlignment string
+
+// Horizontal alignments.
+const (
+	HorizontalAlignLeft   HorizontalAlignment = "LEFT"
+	HorizontalAlignCenter HorizontalAlignment = "CENTER"
+	HorizontalAlignRight  HorizontalAlignment = "RIGHT"
+)
+
+// VerticalAlignment is the vertical alignment of the text of a cell.
+type VerticalAlignment string
+
+// Vertical alignments.
+const (
+	VerticalAlignTop    VerticalAlignment = "TOP"
+	VerticalAlignMiddle VerticalAlignment = "MIDDLE"
+	VerticalAlignBottom VerticalAlignment = "BOTTOM"
+)
+
+// WrapStrategy is how the text of a cell wider than the cell is displayed.
+type WrapStrategy string
+
+// Wrap strategies.
+const (
+	// WrapOverflowCell overflows into the next cells while they're empty.
+	WrapOverflowCell WrapStrategy = "OVERFLOW_CELL"
+	// WrapClip clips the text at the border of the cell.
+	WrapClip WrapStrategy = "CLIP"
+	// WrapWrap wraps the text into lines, growing the height of the row.
+	WrapWrap WrapStrategy = "WRAP"
+)
+
+// ConditionType is the type of a boolean condition.
+type ConditionType string
+
+// Condition types.
+const (
+	// ConditionBoolean is met by TRUE and FALSE, shown as checkboxes by data validation.
+	ConditionBoolean ConditionType = "BOOLEAN"
+	// ConditionOneOfList is met by the values of the condition, shown as a dropdown by data validation.
+	ConditionOneOfList ConditionType = "ONE_OF_LIST"
+	// ConditionOneOfRange is met by the values in the A1 range of the condition, e.g. "=Statuses!A1:A5",
+	// shown as a dropdown by data validation.
+	ConditionOneOfRange ConditionType = "ONE_OF_RANGE"
+	// ConditionNumberGreater is met by numbers greater than the value of the condition.
+	ConditionNumberGreater ConditionType = "NUMBER_GREATER"
+	// ConditionNumberLess is met by numbers less than the value of the condition.
+	ConditionNumberLess ConditionType = "NUMBER_LESS"
+	// ConditionTextContains is met by text containing the value of the condition.
+	ConditionTextContains ConditionType = "TEXT_CONTAINS"
+	// ConditionCustomFormula is met when the formula of the condition, e.g. "=$B1>$C1", evaluates to TRUE.
+	ConditionCustomFormula ConditionType = "CUSTOM_FORMULA"
+)
+
+// InterpolationPointType is how the value of a point of a gradient is interpreted.
+type InterpolationPointType string
+
+// Interpolation point types.
+const (
+	InterpolationMin        InterpolationPointType = "MIN"
+	InterpolationMax        InterpolationPointType = "MAX"
+	InterpolationNumber     InterpolationPointType = "NUMBER"
+	InterpolationPercent    InterpolationPointType = "PERCENT"
+	InterpolationPercentile InterpolationPointType = "PERCENTILE"
+)
+
+// SortOrder is the order of a sort.
+type SortOrder string
+
+// Sort orders.
+const (
+	SortAscending  SortOrder = "ASCENDING"
+	SortDescending SortOrder = "DESCENDING"
+)
+
+// InvalidEnumError is returned when a value is not one of the values of an enum.
+type InvalidEnumError struct {
+	Enum  string
+	Value string
+}
+
+func (e *InvalidEnumError) Error() string {
+	return fmt.Sprintf("invalid %s: %q", e.Enum, e.Value)
+}
+
+// checkEnum returns an *InvalidEnumError unless the value is one of the valid values.
+// The empty value is valid when optional is set.
+func checkEnum(enum, value string, optional bool, valid ...string) error {
+	if optional && value == "" {
+		return nil
+	}
+	for _, v := range valid {
+		if value == v {
+			return nil
+		}
+	}
+	return &InvalidEnumError{Enum: enum, Value: value}
+}
+
+func (d Dimension) validate() error {
+	return checkEnum("Dimension", string(d), false, string(DimensionRows), string(DimensionColumns))
+}
+
+func (t MergeType) validate() error {
+	return checkEnum("MergeType", string(t), false, string(MergeAll), string(MergeColumns), string(MergeRows))
+}
+
+func (t PasteType) validate() error {
+	return checkEnum("PasteType", string(t), false, string(PasteNormal), string(PasteValues), string(PasteFormat),
+		string(PasteNoBorders), string(PasteFormula), string(PasteDataValidation), string(PasteConditionalFormatting))
+}
+
+func (t ChartType) validate() error {
+	return checkEnum("ChartType", string(t), false, string(ChartBar), string(ChartLine), string(ChartArea),
+		string(ChartColumn), string(ChartScatter), string(ChartCombo), string(ChartSteppedArea))
+}
+
+func (p LegendPosition) validate() error {
+	return checkEnum("LegendPosition", string(p), true, string(LegendBottom), string(LegendLeft),
+		string(LegendRight), string(LegendTop), string(LegendNone))
+}
+
+func (p AxisPosition) validate() error {
+	return checkEnum("AxisPosition", string(p), true, string(AxisBottom), string(AxisLeft), string(AxisRight))
+}
+
+func (t NumberFormatType) validate() error {
+	return checkEnum("NumberFormatType", string(t), false, string(NumberFormatText), string(NumberFormatNumber),
+		string(NumberFormatPercent), string(NumberFormatCurrency), string(NumberFormatDate), string(NumberFormatTime),
+		string(NumberFormatDateTime), string(NumberFormatScientific))
+}
+
+func (o SortOrder) validate() error {
+	return checkEnum("SortOrder", string(o), false, string(SortAscending), string(SortDescending))
+}
+
+func (a HorizontalAlignment) validate() error {
+	return checkEnum("HorizontalAlignment", string(a), true, string(HorizontalAlignLeft), string(HorizontalAlignCenter),
+		string(HorizontalAlignRight))
+}
+
+func (a VerticalAlignment) validate() error {
+	return checkEnum("VerticalAlignment", string(a), true, string(VerticalAlignTop), string(VerticalAlignMiddle),
+		string(VerticalAlignBottom))
+}
+
+func (s WrapStrategy) validate() error {
+	return checkEnum("WrapStrategy", string(s), true, string(WrapOverflowCell), string(WrapClip), string(WrapWrap))
+}
+
+// validate checks the enums of the format.
+func (format *CellFormat) validate() error {
+	if format.NumberFormat != nil {
+		if err := format.NumberFormat.Type.validate(); err != nil {
+			return err
+		}
+	}
+	if err := format.HorizontalAlignment.validate(); err != nil {
+		return err
+	}
+	if err := format.VerticalAlignment.validate(); err != nil {
+		return err
+	}
+	if rotation := format.TextRotation; rotation != nil {
+		if rotation.Angle < -90 || rotation.Angle > 90 {
+			return fmt.Errorf("invalid text rotation angle: %d", rotation.Angle)
+		}
+		if rotation.Vertical && rotation.Angle != 0 {
+			return errors.New("text rotation can't be both an angle and vertical")
+		}
+	}
+	return format.WrapStrategy.validate()
+}
+
+func (t ConditionType) validate() error {
+	return checkEnum("ConditionType", string(t), false, string(ConditionBoolean), string(ConditionOneOfList),
+		string(ConditionOneOfRange), string(ConditionNumberGreater), string(ConditionNumberLess), string(ConditionTextContains),
+		string(ConditionCustomFormula))
+}
+
+// validate checks the enums of the basic chart.
+func (spec *BasicChartSpec) validate() error {
+	if err := spec.ChartType.validate(); err != nil {
+		return err
+	}
+	if err := spec.LegendPosition.validate(); err != nil {
+		return err
+	}
+	for _, axis := range spec.Axis {
+		if err := axis.Position.validate(); err != nil {
+			return err
+		}
+	}
+	for _, series := range spec.Series {
+		if err := series.TargetAxis.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks the enums of the options.
+func (o *callOptions) validate() error {
+	if err := checkEnum("ValueInputOption", string(o.valueInputOption), false,
+		string(ValueInputRaw), string(ValueInputUserEntered)); err != nil {
+		return err
+	}
+	if err := checkEnum("InsertDataOption", string(o.insertDataOption), true,
+		string(InsertDataOverwrite), string(InsertDataInsertRows)); err != nil {
+		return err
+	}
+	renderOptions := []string{string(ValueRenderFormatted), string(ValueRenderUnformatted), string(ValueRenderFormula)}
+	if err := checkEnum("ValueRenderOption", string(o.valueRenderOption), true, renderOptions...); err != nil {
+		return err
+	}
+	if err := checkEnum("ValueRenderOption", string(o.responseValueRenderOption), true, renderOptions...); err != nil {
+		return err
+	}
+	if err := checkEnum("DateTimeRenderOption", string(o.dateTimeRenderOption), true,
+		string(DateTimeRenderSerialNumber), string(DateTimeRenderFormattedString)); err != nil {
+		return err
+	}
+	if o.majorDimension != "" {
+		return o.majorDimension.validate()
+	}
+	return nil
+}
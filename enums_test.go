@@ -0,0 +1,55 @@
+package spreadsheet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnumValidation(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(DimensionRows.validate())
+	assert.EqualError(Dimension("ROW").validate(), `invalid Dimension: "ROW"`)
+	assert.NoError(MergeColumns.validate())
+	assert.Error(MergeType("").validate())
+	assert.NoError(PasteValues.validate())
+	assert.Error(PasteType("PASTE_VALUE").validate())
+	assert.NoError(LegendPosition("").validate())
+	assert.Error(LegendPosition("BOTTOM").validate())
+
+	spec := &BasicChartSpec{ChartType: ChartColumn, Axis: []BasicChartAxis{{Position: AxisBottom}}}
+	assert.NoError(spec.validate())
+	spec.Series = []BasicChartSeries{{TargetAxis: "LEFT"}}
+	assert.EqualError(spec.validate(), `invalid AxisPosition: "LEFT"`)
+	spec.ChartType = "PIE"
+	assert.EqualError(spec.validate(), `invalid ChartType: "PIE"`)
+}
+
+func TestCallOptionsValidation(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(newCallOptions(nil).validate())
+	assert.NoError(newCallOptions([]CallOption{
+		WithInsertDataOption(InsertDataInsertRows),
+		WithValueRenderOption(ValueRenderFormula),
+		WithValuesInResponse(ValueRenderUnformatted),
+		WithDateTimeRenderOption(DateTimeRenderSerialNumber),
+		WithMajorDimension(DimensionColumns),
+	}).validate())
+	assert.EqualError(newCallOptions([]CallOption{WithValueInputOption("USER_ENTER")}).validate(),
+		`invalid ValueInputOption: "USER_ENTER"`)
+	assert.Error(newCallOptions([]CallOption{WithMajorDimension("rows")}).validate())
+	assert.Error(newCallOptions([]CallOption{WithValuesInResponse("FORMATTED")}).validate())
+}
+
+func TestUpdateRequestValidation(t *testing.T) {
+	assert := assert.New(t)
+	r, err := newUpdateRequest(&Spreadsheet{})
+	assert.NoError(err)
+	sheet := &Sheet{}
+	r.InsertDimension(sheet, DimensionRows, 0, 1).
+		MergeCells(GridRange{}, "MERGE").
+		DeleteDimension(sheet, "COLUMN", 0, 1)
+	_, err = r.Do(context.Background())
+	assert.EqualError(err, `invalid MergeType: "MERGE"`)
+}
@@ -0,0 +1,110 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OutOfRangeError is returned when a cell outside of the grid of a sheet is accessed.
+type OutOfRangeError struct {
+	Row         int
+	Column      int
+	RowCount    uint
+	ColumnCount uint
+}
+
+func (e *OutOfRangeError) Error() string {
+	return fmt.Sprintf("cell (%d, %d) is out of the %dx%d grid", e.Row, e.Column, e.RowCount, e.ColumnCount)
+}
+
+// APIError is an error returned by the API, e.g. a Code of 429 when the quota is exceeded.
+// Use errors.As or a type assertion on *APIError to branch on the code or the status.
+type APIError struct {
+	// Code is the HTTP status code.
+	Code int `json:"code"`
+	// Status is the canonical status, e.g. "PERMISSION_DENIED" or "RESOURCE_EXHAUSTED".
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	// Details are the objects describing the error further, each with its "@type".
+	Details []map[string]interface{} `json:"details,omitempty"`
+	// RetryAfter is the wait asked by the Retry-After header of the response, if any.
+	RetryAfter time.Duration `json:"-"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("error status: %s, code:%d, message: %s", e.Status, e.Code, e.Message)
+}
+
+// maxErrorMessageLength is the length the plain text bodies are cut to when used as error messages.
+const maxErrorMessageLength = 200
+
+// canonicalStatuses are the statuses of the errors without a JSON body, by HTTP status code.
+var canonicalStatuses = map[int]string{
+	http.StatusBadRequest:          "INVALID_ARGUMENT",
+	http.StatusUnauthorized:        "UNAUTHENTICATED",
+	http.StatusForbidden:           "PERMISSION_DENIED",
+	http.StatusNotFound:            "NOT_FOUND",
+	http.StatusConflict:            "ABORTED",
+	http.StatusTooManyRequests:     "RESOURCE_EXHAUSTED",
+	http.StatusInternalServerError: "INTERNAL",
+	http.StatusNotImplemented:      "UNIMPLEMENTED",
+	http.StatusServiceUnavailable:  "UNAVAILABLE",
+	http.StatusGatewayTimeout:      "DEADLINE_EXCEEDED",
+}
+
+// checkResponse returns an *APIError for the responses with a status other than 2xx.
+// The error is read from the JSON body when there is one. Other bodies, like the HTML pages
+// of a 502 from a proxy, only give their text as the message when it is plain text.
+func checkResponse(status int, header http.Header, body []byte, now time.Time) *APIError {
+	if status >= 200 && status < 300 {
+		return nil
+	}
+	var res struct {
+		Error *APIError `json:"error"`
+	}
+	if json.Unmarshal(body, &res) != nil || res.Error == nil {
+		res.Error = &APIError{}
+	}
+	e := res.Error
+	e.Code = status
+	if e.Status == "" {
+		e.Status = canonicalStatuses[status]
+		if e.Status == "" {
+			e.Status = "UNKNOWN"
+		}
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(status)
+		text := strings.TrimSpace(string(body))
+		if strings.HasPrefix(header.Get("Content-Type"), "text/plain") && text != "" {
+			if len(text) > maxErrorMessageLength {
+				text = text[:maxErrorMessageLength] + "..."
+			}
+			e.Message = text
+		}
+	}
+	e.RetryAfter = retryAfter(header, now)
+	return e
+}
+
+// retryAfter parses the Retry-After header, either a number of seconds or an HTTP date.
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
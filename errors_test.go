@@ -0,0 +1,82 @@
+package spreadsheet
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIError(t *testing.T) {
+	assert := assert.New(t)
+	s := NewServiceWithClient(&http.Client{Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusForbidden, `{"error":{
+			"code":403,
+			"message":"The caller does not have permission",
+			"status":"PERMISSION_DENIED",
+			"details":[{"@type":"type.googleapis.com/google.rpc.ErrorInfo","reason":"ACCESS_TOKEN_SCOPE_INSUFFICIENT"}]
+		}}`), nil
+	})})
+
+	_, err := s.GetValues("abc", "A1")
+	apiErr, ok := err.(*APIError)
+	if assert.True(ok) {
+		assert.Equal(http.StatusForbidden, apiErr.Code)
+		assert.Equal("PERMISSION_DENIED", apiErr.Status)
+		assert.Equal("The caller does not have permission", apiErr.Message)
+		assert.Equal([]map[string]interface{}{{
+			"@type":  "type.googleapis.com/google.rpc.ErrorInfo",
+			"reason": "ACCESS_TOKEN_SCOPE_INSUFFICIENT",
+		}}, apiErr.Details)
+	}
+	assert.EqualError(err, "error status: PERMISSION_DENIED, code:403, message: The caller does not have permission")
+}
+
+func TestCheckResponse(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Nil(checkResponse(http.StatusOK, http.Header{}, []byte("not JSON"), now))
+
+	e := checkResponse(http.StatusBadGateway, http.Header{"Content-Type": {"text/html; charset=UTF-8"}},
+		[]byte("<!DOCTYPE html><html><title>Error 502</title></html>"), now)
+	assert.Equal(&APIError{Code: http.StatusBadGateway, Status: "UNKNOWN", Message: "Bad Gateway"}, e)
+
+	e = checkResponse(http.StatusServiceUnavailable, http.Header{"Content-Type": {"text/plain"}, "Retry-After": {"30"}},
+		[]byte(" backend unavailable\n"), now)
+	assert.Equal(&APIError{
+		Code:       http.StatusServiceUnavailable,
+		Status:     "UNAVAILABLE",
+		Message:    "backend unavailable",
+		RetryAfter: 30 * time.Second,
+	}, e)
+
+	e = checkResponse(http.StatusTooManyRequests, http.Header{"Retry-After": {"Tue, 01 Jan 2019 00:01:00 GMT"}},
+		[]byte(`{"error":{"message":"Quota exceeded"}}`), now)
+	assert.Equal(&APIError{
+		Code:       http.StatusTooManyRequests,
+		Status:     "RESOURCE_EXHAUSTED",
+		Message:    "Quota exceeded",
+		RetryAfter: time.Minute,
+	}, e)
+
+	e = checkResponse(http.StatusNotFound, http.Header{"Content-Type": {"text/plain"}}, []byte(strings.Repeat("x", 300)), now)
+	assert.Equal(strings.Repeat("x", maxErrorMessageLength)+"...", e.Message)
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	for value, expected := range map[string]time.Duration{
+		"":                              0,
+		"5":                             5 * time.Second,
+		"-1":                            0,
+		"soon":                          0,
+		"Tue, 01 Jan 2019 00:00:10 GMT": 10 * time.Second,
+		"Mon, 31 Dec 2018 23:59:00 GMT": 0,
+	} {
+		assert.Equal(expected, retryAfter(http.Header{"Retry-After": {value}}, now), value)
+	}
+}
@@ -0,0 +1,55 @@
+package spreadsheet
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// streamChunkRows is the number of rows fetched at once while streaming a sheet.
+const streamChunkRows = 1000
+
+// StreamCSV fetches the values of the sheet in chunks of rows and writes them to w as CSV.
+// Every chunk is written out before the next one is fetched, so a slow writer slows down
+// the export instead of rows piling up in memory. Trailing empty rows are not written.
+func (sheet *Sheet) StreamCSV(ctx context.Context, w io.Writer, opts ...CallOption) (err error) {
+	writer := csv.NewWriter(w)
+	rowCount := int(sheet.Properties.GridProperties.RowCount)
+	emptyRows := 0
+	for start := 0; start < rowCount; start += streamChunkRows {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		end := start + streamChunkRows
+		if end > rowCount {
+			end = rowCount
+		}
+		var valueRange ValueRange
+		valueRange, err = sheet.GetValuesContext(ctx, fmt.Sprintf("%d:%d", start+1, end), opts...)
+		if err != nil {
+			return
+		}
+		for _, row := range toStrings(valueRange.Values) {
+			if len(row) == 0 {
+				emptyRows++
+				continue
+			}
+			for ; emptyRows > 0; emptyRows-- {
+				if err = writer.Write(nil); err != nil {
+					return
+				}
+			}
+			if err = writer.Write(row); err != nil {
+				return
+			}
+		}
+		// rows missing at the end of the chunk are empty but may be followed by values
+		emptyRows += end - start - len(valueRange.Values)
+		writer.Flush()
+		if err = writer.Error(); err != nil {
+			return
+		}
+	}
+	return
+}
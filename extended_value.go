@@ -1,10 +1,191 @@
 package spreadsheet
 
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
 // ExtendedValue is the kinds of value that a cell in a spreadsheet can have.
+// At most one of the fields is set: IsNumber, IsBool and IsError tell a zero number,
+// false or an error from an unset field.
 type ExtendedValue struct {
 	NumberValue  float64    `json:"numberValue"`
 	StringValue  string     `json:"stringValue"`
 	BoolValue    bool       `json:"boolValue"`
 	FormulaValue string     `json:"formulaValue"`
 	ErrorValue   ErrorValue `json:"errorValue"`
+
+	// kind is the JSON name of the field set, when it's known from the API or from the Go value.
+	kind string
+}
+
+// IsNumber reports whether the value is a number, including zero.
+func (v ExtendedValue) IsNumber() bool {
+	return v.field() == "numberValue"
+}
+
+// IsBool reports whether the value is a boolean, including false.
+func (v ExtendedValue) IsBool() bool {
+	return v.field() == "boolValue"
+}
+
+// IsError reports whether the value is an error.
+func (v ExtendedValue) IsError() bool {
+	return v.field() == "errorValue"
+}
+
+// field returns the JSON name of the field set. Values built without it, e.g. ExtendedValue{NumberValue: 1},
+// are set by their non-zero field.
+func (v ExtendedValue) field() string {
+	switch {
+	case v.kind != "":
+		return v.kind
+	case v.FormulaValue != "":
+		return "formulaValue"
+	case v.ErrorValue.Type != "":
+		return "errorValue"
+	case v.StringValue != "":
+		return "stringValue"
+	case v.NumberValue != 0:
+		return "numberValue"
+	case v.BoolValue:
+		return "boolValue"
+	}
+	return ""
+}
+
+// UnmarshalJSON decodes the value and which of its fields is set.
+func (v *ExtendedValue) UnmarshalJSON(data []byte) (err error) {
+	var value struct {
+		NumberValue  *float64    `json:"numberValue"`
+		StringValue  *string     `json:"stringValue"`
+		BoolValue    *bool       `json:"boolValue"`
+		FormulaValue *string     `json:"formulaValue"`
+		ErrorValue   *ErrorValue `json:"errorValue"`
+	}
+	if err = json.Unmarshal(data, &value); err != nil {
+		return
+	}
+	*v = ExtendedValue{}
+	switch {
+	case value.NumberValue != nil:
+		v.NumberValue, v.kind = *value.NumberValue, "numberValue"
+	case value.StringValue != nil:
+		v.StringValue, v.kind = *value.StringValue, "stringValue"
+	case value.BoolValue != nil:
+		v.BoolValue, v.kind = *value.BoolValue, "boolValue"
+	case value.FormulaValue != nil:
+		v.FormulaValue, v.kind = *value.FormulaValue, "formulaValue"
+	case value.ErrorValue != nil:
+		v.ErrorValue, v.kind = *value.ErrorValue, "errorValue"
+	}
+	return
+}
+
+// MarshalJSON encodes only the field set, as the API accepts one kind of value.
+func (v ExtendedValue) MarshalJSON() ([]byte, error) {
+	var value interface{}
+	field := v.field()
+	switch field {
+	case "numberValue":
+		value = v.NumberValue
+	case "stringValue":
+		value = v.StringValue
+	case "boolValue":
+		value = v.BoolValue
+	case "formulaValue":
+		value = v.FormulaValue
+	case "errorValue":
+		value = v.ErrorValue
+	default:
+		return []byte("null"), nil
+	}
+	return json.Marshal(map[string]interface{}{field: value})
+}
+
+// errorDisplays are how the types of errors are displayed in cells.
+var errorDisplays = map[string]string{
+	"ERROR":          "#ERROR!",
+	"NULL_VALUE":     "#NULL!",
+	"DIVIDE_BY_ZERO": "#DIV/0!",
+	"VALUE":          "#VALUE!",
+	"REF":            "#REF!",
+	"NAME":           "#NAME?",
+	"NUM":            "#NUM!",
+	"N_A":            "#N/A",
+	"LOADING":        "Loading...",
+}
+
+// String returns the unformatted value as a string.
+// Formulas are returned as they are, not evaluated.
+func (v ExtendedValue) String() string {
+	switch {
+	case v.FormulaValue != "":
+		return v.FormulaValue
+	case v.IsNumber():
+		return strconv.FormatFloat(v.NumberValue, 'f', -1, 64)
+	case v.IsBool():
+		if v.BoolValue {
+			return "TRUE"
+		}
+		return "FALSE"
+	case v.IsError():
+		if display, ok := errorDisplays[v.ErrorValue.Type]; ok {
+			return display
+		}
+		return "#" + v.ErrorValue.Type
+	}
+	return v.StringValue
+}
+
+// pointer returns the value, or nil when no field is set.
+func (v ExtendedValue) pointer() *ExtendedValue {
+	if v.field() == "" {
+		return nil
+	}
+	return &v
+}
+
+// extendedValueOf converts the Go value into the value of a cell.
+// Strings beginning with "=" are formulas.
+func extendedValueOf(value interface{}) ExtendedValue {
+	switch v := value.(type) {
+	case nil:
+		return ExtendedValue{}
+	case *ExtendedValue:
+		if v == nil {
+			return ExtendedValue{}
+		}
+		return *v
+	case ExtendedValue:
+		return v
+	case string:
+		if strings.HasPrefix(v, "=") {
+			return ExtendedValue{FormulaValue: v}
+		}
+		return ExtendedValue{StringValue: v}
+	case bool:
+		return ExtendedValue{BoolValue: v, kind: "boolValue"}
+	}
+	rv := reflect.ValueOf(value)
+	var f float64
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f = float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		f = float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		f = rv.Float()
+	default:
+		return ExtendedValue{StringValue: fmt.Sprint(value)}
+	}
+	return ExtendedValue{NumberValue: f, kind: "numberValue"}
+}
+
+// isNumber reports whether the Go value is written as a number.
+func isNumber(value interface{}) bool {
+	return extendedValueOf(value).IsNumber()
 }
@@ -0,0 +1,63 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtendedValueString(t *testing.T) {
+	assert := assert.New(t)
+	values := map[string]string{
+		`{}`:                                 "",
+		`{"numberValue": 0}`:                 "0",
+		`{"numberValue": 1234.5}`:            "1234.5",
+		`{"boolValue": false}`:               "FALSE",
+		`{"stringValue": "abc"}`:             "abc",
+		`{"formulaValue": "=A1"}`:            "=A1",
+		`{"errorValue": {"type": "N_A"}}`:    "#N/A",
+		`{"errorValue": {"type": "CUSTOM"}}`: "#CUSTOM",
+	}
+	for data, expected := range values {
+		var v ExtendedValue
+		assert.NoError(json.Unmarshal([]byte(data), &v))
+		assert.Equal(expected, v.String(), data)
+	}
+}
+
+func TestExtendedValueKind(t *testing.T) {
+	assert := assert.New(t)
+	var v ExtendedValue
+	assert.NoError(json.Unmarshal([]byte(`{"numberValue": 0}`), &v))
+	assert.True(v.IsNumber())
+	assert.False(v.IsBool())
+	assert.NoError(json.Unmarshal([]byte(`{"boolValue": false}`), &v))
+	assert.True(v.IsBool())
+	assert.False(v.IsNumber())
+	assert.NoError(json.Unmarshal([]byte(`{"stringValue": ""}`), &v))
+	assert.False(v.IsNumber() || v.IsBool() || v.IsError())
+	assert.NoError(json.Unmarshal([]byte(`{"errorValue": {"type": "REF"}}`), &v))
+	assert.True(v.IsError())
+
+	assert.True(ExtendedValue{NumberValue: 2}.IsNumber())
+	assert.False(ExtendedValue{}.IsNumber())
+	assert.True(extendedValueOf(0).IsNumber())
+	assert.True(extendedValueOf(false).IsBool())
+
+	values := map[string]ExtendedValue{
+		`{"numberValue":0}`:      extendedValueOf(0),
+		`{"boolValue":false}`:    extendedValueOf(false),
+		`{"stringValue":"a"}`:    extendedValueOf("a"),
+		`{"formulaValue":"=A1"}`: extendedValueOf("=A1"),
+		`{"numberValue":1.5}`:    {NumberValue: 1.5},
+		`null`:                   {},
+		`{"errorValue":{"type":"N_A","message":""}}`: {ErrorValue: ErrorValue{Type: "N_A"}},
+	}
+	for expected, v := range values {
+		data, err := json.Marshal(v)
+		if assert.NoError(err, expected) {
+			assert.Equal(expected, string(data))
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package spreadsheet
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// FetchSpreadsheetConcurrently fetches the spreadsheet by requests of one A1 range each, or of one sheet each
+// when there are no ranges, sent up to concurrency at a time, and assembles them into one spreadsheet.
+// It's faster than FetchSpreadsheet for spreadsheets with many large sheets.
+// A concurrency of 0 sends all the requests at once. The first error cancels the other requests.
+func (s *Service) FetchSpreadsheetConcurrently(id string, ranges []string, concurrency int, opts ...CallOption) (spreadsheet Spreadsheet, err error) {
+	spreadsheet, err = s.FetchSpreadsheetConcurrentlyContext(context.Background(), id, ranges, concurrency, opts...)
+	return
+}
+
+// FetchSpreadsheetConcurrentlyContext is like FetchSpreadsheetConcurrently with the context of the requests.
+func (s *Service) FetchSpreadsheetConcurrentlyContext(ctx context.Context, id string, ranges []string, concurrency int, opts ...CallOption) (spreadsheet Spreadsheet, err error) {
+	o := s.newCallOptions(opts)
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	spreadsheet, err = s.FetchSpreadsheetMetadataContext(ctx, id)
+	if err != nil {
+		return
+	}
+	if len(ranges) == 0 {
+		for i := range spreadsheet.Sheets {
+			ranges = append(ranges, quoteSheetTitle(spreadsheet.Sheets[i].Properties.Title))
+		}
+	}
+	fetched, err := s.fetchRanges(ctx, id, ranges, concurrency, opts)
+	if err != nil {
+		return
+	}
+	for i := range spreadsheet.Sheets {
+		sheet := &spreadsheet.Sheets[i]
+		var gridData []GridData
+		for j := range fetched {
+			if loaded, e := fetched[j].SheetByID(sheet.Properties.ID); e == nil {
+				gridData = append(gridData, loaded.Data.GridData...)
+			}
+		}
+		if err = sheet.assemble(gridData, o); err != nil {
+			return
+		}
+	}
+	spreadsheet.linkSheets()
+	return
+}
+
+// fetchRanges fetches the spreadsheet with the cells of each range, up to concurrency at a time.
+func (s *Service) fetchRanges(ctx context.Context, id string, ranges []string, concurrency int, opts []CallOption) (fetched []Spreadsheet, err error) {
+	if concurrency <= 0 || concurrency > len(ranges) {
+		concurrency = len(ranges)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	fetched = make([]Spreadsheet, len(ranges))
+	var (
+		wg   sync.WaitGroup
+		once sync.Once
+	)
+	slots := make(chan struct{}, concurrency)
+	for i := range ranges {
+		slots <- struct{}{}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			var e error
+			fetched[i], e = s.fetchSpreadsheet(ctx, id, []string{ranges[i]}, opts)
+			if e != nil {
+				once.Do(func() {
+					err = e
+					cancel()
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+	if err == nil {
+		err = ctx.Err()
+	}
+	return
+}
+
+// assemble makes the cells of the sheet from the grid data of the fetched ranges, rendered by the options.
+func (sheet *Sheet) assemble(gridData []GridData, o *callOptions) (err error) {
+	sheet.Data.GridData = gridData
+	sheet.sparse = o.sparseCells
+	sheet.loadCells()
+	if o.valueRenderOption != "" && o.valueRenderOption != ValueRenderFormatted {
+		sheet.renderValues(o.valueRenderOption)
+	}
+	sheet.TmpData, err = json.Marshal(map[string]interface{}{
+		"properties": sheet.Properties,
+		"data":       gridData,
+	})
+	return
+}
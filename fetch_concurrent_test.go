@@ -0,0 +1,72 @@
+package spreadsheet
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchSpreadsheetConcurrently(t *testing.T) {
+	assert := assert.New(t)
+	var (
+		mu               sync.Mutex
+		inFlight, maxOut int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ranges := r.URL.Query()["ranges"]
+		if len(ranges) == 0 {
+			w.Write([]byte(`{"spreadsheetId":"abc","sheets":[
+				{"properties":{"sheetId":1,"title":"A"}},
+				{"properties":{"sheetId":2,"title":"B"}},
+				{"properties":{"sheetId":3,"title":"C"}}
+			]}`))
+			return
+		}
+		mu.Lock()
+		inFlight++
+		if inFlight > maxOut {
+			maxOut = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		switch ranges[0] {
+		case "'A'":
+			w.Write([]byte(`{"spreadsheetId":"abc","sheets":[{"properties":{"sheetId":1,"title":"A"},
+				"data":[{"rowData":[{"values":[{"formattedValue":"a1"},{"formattedValue":"b1"}]}]}]}]}`))
+		case "'C'":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{}`))
+		default:
+			sheet := map[string]string{"'B'!A1": `"startRow":0`, "'B'!C3": `"startRow":2,"startColumn":2`}[ranges[0]]
+			fmt.Fprintf(w, `{"spreadsheetId":"abc","sheets":[{"properties":{"sheetId":2,"title":"B"},
+				"data":[{%s,"rowData":[{"values":[{"formattedValue":"%s"}]}]}]}]}`, sheet, ranges[0])
+		}
+	}))
+	defer server.Close()
+
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{}))
+	spreadsheet, err := s.FetchSpreadsheetConcurrently("abc", []string{"'A'", "'B'!A1", "'B'!C3"}, 2)
+	assert.NoError(err)
+	assert.Equal(2, maxOut)
+	if assert.Len(spreadsheet.Sheets, 3) {
+		a, b := &spreadsheet.Sheets[0], &spreadsheet.Sheets[1]
+		assert.Equal("b1", a.Rows[0][1].Value)
+		assert.Equal("'B'!A1", b.Rows[0][0].Value)
+		assert.Equal("'B'!C3", b.Rows[2][2].Value)
+		assert.Equal("'B'!C3", b.Columns[2][2].Value)
+		assert.Contains(string(b.TmpData), `"title":"B"`)
+		assert.Empty(spreadsheet.Sheets[2].Data.GridData)
+	}
+
+	_, err = s.FetchSpreadsheetConcurrently("abc", nil, 0)
+	assert.Error(err)
+}
@@ -0,0 +1,71 @@
+package spreadsheet
+
+import "context"
+
+// FilterView is a named filter of a range, which can be applied by each user.
+type FilterView struct {
+	FilterViewID uint      `json:"filterViewId,omitempty"`
+	Title        string    `json:"title,omitempty"`
+	Range        GridRange `json:"range"`
+	// NamedRangeID is set instead of Range when the filter view is of a named range.
+	NamedRangeID string       `json:"namedRangeId,omitempty"`
+	SortSpecs    []SortSpec   `json:"sortSpecs,omitempty"`
+	FilterSpecs  []FilterSpec `json:"filterSpecs,omitempty"`
+}
+
+// BasicFilter is the filter of a sheet, applied for all users.
+type BasicFilter struct {
+	Range       GridRange    `json:"range"`
+	SortSpecs   []SortSpec   `json:"sortSpecs,omitempty"`
+	FilterSpecs []FilterSpec `json:"filterSpecs,omitempty"`
+}
+
+// SortSpec is the sort order of a column.
+type SortSpec struct {
+	DimensionIndex int       `json:"dimensionIndex"`
+	SortOrder      SortOrder `json:"sortOrder"`
+}
+
+// FilterSpec is the criteria of the values shown in a column.
+type FilterSpec struct {
+	ColumnIndex    int            `json:"columnIndex"`
+	FilterCriteria FilterCriteria `json:"filterCriteria"`
+}
+
+// FilterCriteria is the criteria of the values shown by a filter.
+type FilterCriteria struct {
+	HiddenValues []string          `json:"hiddenValues,omitempty"`
+	Condition    *BooleanCondition `json:"condition,omitempty"`
+}
+
+// FilterViews returns the filter views of the sheet as of when it was fetched.
+func (sheet *Sheet) FilterViews() []FilterView {
+	return append([]FilterView(nil), sheet.filterViews...)
+}
+
+// BasicFilter returns the basic filter of the sheet as of when it was fetched, if any.
+func (sheet *Sheet) BasicFilter() (filter BasicFilter, ok bool) {
+	if sheet.basicFilter == nil {
+		return
+	}
+	filter, ok = *sheet.basicFilter, true
+	return
+}
+
+// UpdateFilterView updates the fields of the filter view of the sheet, e.g. "title" or "filterSpecs".
+// The filter view is the one with the ID of the filter view.
+func (s *Service) UpdateFilterView(sheet *Sheet, filterView FilterView, fields string) (err error) {
+	err = s.UpdateFilterViewContext(context.Background(), sheet, filterView, fields)
+	return
+}
+
+// UpdateFilterViewContext is like UpdateFilterView with the context of the request.
+func (s *Service) UpdateFilterViewContext(ctx context.Context, sheet *Sheet, filterView FilterView, fields string) (err error) {
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	filterView.Range.SheetID = sheet.Properties.ID
+	_, err = r.UpdateFilterView(filterView, fields).Do(ctx)
+	return
+}
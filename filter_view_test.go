@@ -0,0 +1,77 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSheetFilterViews(t *testing.T) {
+	assert := assert.New(t)
+	sheet := &Sheet{}
+	err := json.Unmarshal([]byte(`{
+		"properties": {"sheetId": 7},
+		"filterViews": [{
+			"filterViewId": 11,
+			"title": "Open",
+			"range": {"sheetId": 7, "endColumnIndex": 4},
+			"sortSpecs": [{"dimensionIndex": 1, "sortOrder": "DESCENDING"}],
+			"filterSpecs": [{"columnIndex": 2, "filterCriteria": {"hiddenValues": ["done"]}}]
+		}],
+		"basicFilter": {
+			"range": {"sheetId": 7, "endColumnIndex": 4},
+			"filterSpecs": [{"columnIndex": 3, "filterCriteria": {"condition": {"type": "NUMBER_GREATER", "values": [{"userEnteredValue": "10"}]}}}]
+		}
+	}`), sheet)
+	assert.NoError(err)
+
+	assert.Equal([]FilterView{{
+		FilterViewID: 11,
+		Title:        "Open",
+		Range:        GridRange{SheetID: 7, EndColumnIndex: 4},
+		SortSpecs:    []SortSpec{{DimensionIndex: 1, SortOrder: SortDescending}},
+		FilterSpecs:  []FilterSpec{{ColumnIndex: 2, FilterCriteria: FilterCriteria{HiddenValues: []string{"done"}}}},
+	}}, sheet.FilterViews())
+
+	filter, ok := sheet.BasicFilter()
+	assert.True(ok)
+	assert.Equal(GridRange{SheetID: 7, EndColumnIndex: 4}, filter.Range)
+	assert.Equal(&BooleanCondition{Type: ConditionNumberGreater, Values: []ConditionValue{{UserEnteredValue: "10"}}},
+		filter.FilterSpecs[0].FilterCriteria.Condition)
+
+	assert.NoError(json.Unmarshal([]byte(`{"properties": {"sheetId": 8}}`), sheet))
+	_, ok = sheet.BasicFilter()
+	assert.False(ok)
+	assert.Empty(sheet.FilterViews())
+}
+
+func TestUpdateFilterView(t *testing.T) {
+	assert := assert.New(t)
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Requests []map[string]interface{} `json:"requests"`
+		}
+		assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+		requests = body.Requests
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	spreadsheet := &Spreadsheet{ID: "abc", service: s, Sheets: []Sheet{{Properties: SheetProperties{ID: 7, Title: "Tasks"}}}}
+	sheet := &spreadsheet.Sheets[0]
+	sheet.Spreadsheet = spreadsheet
+
+	assert.NoError(s.UpdateFilterView(sheet, FilterView{FilterViewID: 11, Title: "Closed"}, "title"))
+	assert.Equal([]map[string]interface{}{{"updateFilterView": map[string]interface{}{
+		"filter": map[string]interface{}{"filterViewId": 11.0, "title": "Closed", "range": map[string]interface{}{"sheetId": 7.0}},
+		"fields": "title",
+	}}}, requests)
+
+	err := s.UpdateFilterView(sheet, FilterView{FilterViewID: 11, SortSpecs: []SortSpec{{SortOrder: "UP"}}}, "sortSpecs")
+	assert.EqualError(err, `invalid SortOrder: "UP"`)
+}
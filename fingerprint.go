@@ -0,0 +1,109 @@
+package spreadsheet
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"sort"
+)
+
+// Fingerprint is a content based fingerprint of a spreadsheet.
+// Two spreadsheets with the same sheet titles and cell values have the same fingerprint,
+// regardless of their IDs, formatting and trailing empty cells.
+type Fingerprint struct {
+	// Hash is the hash of the whole spreadsheet.
+	Hash string
+	// Sheets are the hashes of the sheets by title.
+	Sheets map[string]string
+}
+
+// Fingerprint computes the content based fingerprint of the spreadsheet.
+func (spreadsheet *Spreadsheet) Fingerprint() Fingerprint {
+	fp := Fingerprint{Sheets: make(map[string]string, len(spreadsheet.Sheets))}
+	sheets := make([]*Sheet, len(spreadsheet.Sheets))
+	for i := range spreadsheet.Sheets {
+		sheets[i] = &spreadsheet.Sheets[i]
+	}
+	sort.SliceStable(sheets, func(i, j int) bool {
+		return sheets[i].Properties.Index < sheets[j].Properties.Index
+	})
+	h := sha256.New()
+	for _, sheet := range sheets {
+		sum := sheet.Hash()
+		fp.Sheets[sheet.Properties.Title] = sum
+		writeString(h, sheet.Properties.Title)
+		writeString(h, sum)
+	}
+	fp.Hash = hex.EncodeToString(h.Sum(nil))
+	return fp
+}
+
+// Hash computes the content based hash of the cell values of the sheet.
+func (sheet *Sheet) Hash() string {
+	sheet.mu.Lock()
+	defer sheet.mu.Unlock()
+	h := sha256.New()
+	rows := rowHashes(sheet.Rows)
+	n := len(rows)
+	for n > 0 && rows[n-1] == emptyRowHash {
+		n--
+	}
+	for _, sum := range rows[:n] {
+		h.Write(sum[:])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Equal reports whether the fingerprints are of spreadsheets with the same content.
+func (fp Fingerprint) Equal(other Fingerprint) bool {
+	return fp.Hash == other.Hash
+}
+
+// Diff returns the titles of the sheets whose content differs or which exist only in one of the spreadsheets.
+func (fp Fingerprint) Diff(other Fingerprint) (titles []string) {
+	for title, sum := range fp.Sheets {
+		if other.Sheets[title] != sum {
+			titles = append(titles, title)
+		}
+	}
+	for title := range other.Sheets {
+		if _, ok := fp.Sheets[title]; !ok {
+			titles = append(titles, title)
+		}
+	}
+	sort.Strings(titles)
+	return
+}
+
+type rowHash [sha256.Size]byte
+
+var emptyRowHash = hashRow(nil)
+
+func rowHashes(rows [][]Cell) []rowHash {
+	sums := make([]rowHash, len(rows))
+	for i, row := range rows {
+		sums[i] = hashRow(row)
+	}
+	return sums
+}
+
+// hashRow hashes the values of the row ignoring trailing empty cells.
+func hashRow(row []Cell) (sum rowHash) {
+	n := len(row)
+	for n > 0 && row[n-1].Value == "" {
+		n--
+	}
+	h := sha256.New()
+	for _, cell := range row[:n] {
+		writeString(h, cell.Value)
+	}
+	copy(sum[:], h.Sum(nil))
+	return
+}
+
+// writeString writes the length prefixed string so that concatenations can not collide.
+func writeString(h interface{ Write([]byte) (int, error) }, s string) {
+	var n [binary.MaxVarintLen64]byte
+	h.Write(n[:binary.PutUvarint(n[:], uint64(len(s)))])
+	h.Write([]byte(s))
+}
@@ -0,0 +1,40 @@
+package spreadsheet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSheet(title string, index uint, values ...[]string) (sheet Sheet) {
+	sheet.Properties = SheetProperties{Title: title, Index: index}
+	for i, row := range values {
+		for j, v := range row {
+			sheet.Update(i, j, v)
+		}
+	}
+	return
+}
+
+func TestFingerprint(t *testing.T) {
+	assert := assert.New(t)
+	a := &Spreadsheet{ID: "a", Sheets: []Sheet{
+		newTestSheet("one", 0, []string{"a", "b"}, []string{"c"}),
+		newTestSheet("two", 1, []string{"x"}),
+	}}
+	b := &Spreadsheet{ID: "b", Sheets: []Sheet{
+		newTestSheet("two", 1, []string{"x", ""}, []string{}, []string{""}),
+		newTestSheet("one", 0, []string{"a", "b"}, []string{"c"}),
+	}}
+	fa, fb := a.Fingerprint(), b.Fingerprint()
+	assert.True(fa.Equal(fb))
+	assert.Empty(fa.Diff(fb))
+
+	c := &Spreadsheet{Sheets: []Sheet{
+		newTestSheet("one", 0, []string{"ab"}, []string{"c"}),
+		newTestSheet("three", 1, []string{"x"}),
+	}}
+	fc := c.Fingerprint()
+	assert.False(fa.Equal(fc))
+	assert.Equal([]string{"one", "three", "two"}, fa.Diff(fc))
+}
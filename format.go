@@ -0,0 +1,159 @@
+package spreadsheet
+
+import (
+	"context"
+	"strings"
+)
+
+// SetCellFormat sets the fields of the format of the cells in the range of the sheet to the ones of the format,
+// e.g. "numberFormat" or "textFormat.bold". Fields missing from the format are reset.
+// The sheet ID of the range is the one of the sheet.
+func (s *Service) SetCellFormat(sheet *Sheet, gridRange GridRange, format CellFormat, fields string) (err error) {
+	err = s.SetCellFormatContext(context.Background(), sheet, gridRange, format, fields)
+	return
+}
+
+// SetCellFormatContext is like SetCellFormat with the context of the request.
+func (s *Service) SetCellFormatContext(ctx context.Context, sheet *Sheet, gridRange GridRange, format CellFormat, fields string) (err error) {
+	r, err := newUpdateRequest(sheet.Spreadsheet)
+	if err != nil {
+		return
+	}
+	r.check(format.validate())
+	gridRange.SheetID = sheet.Properties.ID
+	_, err = r.RepeatCell(gridRange, CellData{UserEnteredFormat: &format}, prefixFields("userEnteredFormat.", fields)).Do(ctx)
+	return
+}
+
+// prefixFields prefixes each of the comma separated fields.
+func prefixFields(prefix, fields string) string {
+	return prefix + strings.Replace(fields, ",", ","+prefix, -1)
+}
+
+// SetNumberFormat sets how the numbers of the cells in the range of the sheet are displayed,
+// e.g. NumberFormat{Type: NumberFormatCurrency, Pattern: "#,##0.00 [$€]"}.
+func (sheet *Sheet) SetNumberFormat(gridRange GridRange, format NumberFormat) (err error) {
+	err = sheet.SetNumberFormatContext(context.Background(), gridRange, format)
+	return
+}
+
+// SetNumberFormatContext is like SetNumberFormat with the context of the request.
+func (sheet *Sheet) SetNumberFormatContext(ctx context.Context, gridRange GridRange, format NumberFormat) (err error) {
+	err = sheet.Spreadsheet.service.SetCellFormatContext(ctx, sheet, gridRange, CellFormat{NumberFormat: &format}, "numberFormat")
+	return
+}
+
+// SetTextFormat sets the format of the text of the cells in the range of the sheet,
+// e.g. TextFormat{Bold: true, FontSize: 12} for a header row.
+func (sheet *Sheet) SetTextFormat(gridRange GridRange, format TextFormat) (err error) {
+	err = sheet.SetTextFormatContext(context.Background(), gridRange, format)
+	return
+}
+
+// SetTextFormatContext is like SetTextFormat with the context of the request.
+func (sheet *Sheet) SetTextFormatContext(ctx context.Context, gridRange GridRange, format TextFormat) (err error) {
+	err = sheet.Spreadsheet.service.SetCellFormatContext(ctx, sheet, gridRange, CellFormat{TextFormat: &format}, "textFormat")
+	return
+}
+
+// SetBold makes the text of the cells in the range of the sheet bold or not, keeping the rest of its format.
+func (sheet *Sheet) SetBold(gridRange GridRange, bold bool) (err error) {
+	err = sheet.SetBoldContext(context.Background(), gridRange, bold)
+	return
+}
+
+// SetBoldContext is like SetBold with the context of the request.
+func (sheet *Sheet) SetBoldContext(ctx context.Context, gridRange GridRange, bold bool) (err error) {
+	err = sheet.Spreadsheet.service.SetCellFormatContext(ctx, sheet, gridRange, CellFormat{TextFormat: &TextFormat{Bold: bold}}, "textFormat.bold")
+	return
+}
+
+// SetFontSize sets the font size of the text of the cells in the range of the sheet, keeping the rest of its format.
+func (sheet *Sheet) SetFontSize(gridRange GridRange, size int) (err error) {
+	err = sheet.SetFontSizeContext(context.Background(), gridRange, size)
+	return
+}
+
+// SetFontSizeContext is like SetFontSize with the context of the request.
+func (sheet *Sheet) SetFontSizeContext(ctx context.Context, gridRange GridRange, size int) (err error) {
+	err = sheet.Spreadsheet.service.SetCellFormatContext(ctx, sheet, gridRange, CellFormat{TextFormat: &TextFormat{FontSize: size}}, "textFormat.fontSize")
+	return
+}
+
+// SetForegroundColor sets the color of the text of the cells in the range of the sheet, keeping the rest of its format.
+func (sheet *Sheet) SetForegroundColor(gridRange GridRange, color Color) (err error) {
+	err = sheet.SetForegroundColorContext(context.Background(), gridRange, color)
+	return
+}
+
+// SetForegroundColorContext is like SetForegroundColor with the context of the request.
+func (sheet *Sheet) SetForegroundColorContext(ctx context.Context, gridRange GridRange, color Color) (err error) {
+	err = sheet.Spreadsheet.service.SetCellFormatContext(ctx, sheet, gridRange, CellFormat{TextFormat: &TextFormat{ForegroundColor: &color}}, "textFormat.foregroundColor")
+	return
+}
+
+// SetBackgroundColor sets the background color of the cells in the range of the sheet,
+// e.g. RGB(0xf2, 0xde, 0xde) to highlight failures.
+func (sheet *Sheet) SetBackgroundColor(gridRange GridRange, color Color) (err error) {
+	err = sheet.SetBackgroundColorContext(context.Background(), gridRange, color)
+	return
+}
+
+// SetBackgroundColorContext is like SetBackgroundColor with the context of the request.
+func (sheet *Sheet) SetBackgroundColorContext(ctx context.Context, gridRange GridRange, color Color) (err error) {
+	err = sheet.Spreadsheet.service.SetCellFormatContext(ctx, sheet, gridRange, CellFormat{BackgroundColor: &color}, "backgroundColor")
+	return
+}
+
+// SetAlignment sets the alignment of the text of the cells in the range of the sheet,
+// e.g. HorizontalAlignCenter and VerticalAlignMiddle for a header row. Empty alignments are reset to the default.
+func (sheet *Sheet) SetAlignment(gridRange GridRange, horizontal HorizontalAlignment, vertical VerticalAlignment) (err error) {
+	err = sheet.SetAlignmentContext(context.Background(), gridRange, horizontal, vertical)
+	return
+}
+
+// SetAlignmentContext is like SetAlignment with the context of the request.
+func (sheet *Sheet) SetAlignmentContext(ctx context.Context, gridRange GridRange, horizontal HorizontalAlignment, vertical VerticalAlignment) (err error) {
+	format := CellFormat{HorizontalAlignment: horizontal, VerticalAlignment: vertical}
+	err = sheet.Spreadsheet.service.SetCellFormatContext(ctx, sheet, gridRange, format, "horizontalAlignment,verticalAlignment")
+	return
+}
+
+// SetWrapStrategy sets how the text of the cells in the range of the sheet is displayed when it's wider than the cells.
+func (sheet *Sheet) SetWrapStrategy(gridRange GridRange, strategy WrapStrategy) (err error) {
+	err = sheet.SetWrapStrategyContext(context.Background(), gridRange, strategy)
+	return
+}
+
+// SetWrapStrategyContext is like SetWrapStrategy with the context of the request.
+func (sheet *Sheet) SetWrapStrategyContext(ctx context.Context, gridRange GridRange, strategy WrapStrategy) (err error) {
+	err = sheet.Spreadsheet.service.SetCellFormatContext(ctx, sheet, gridRange, CellFormat{WrapStrategy: strategy}, "wrapStrategy")
+	return
+}
+
+// SetTextRotation rotates the text of the cells in the range of the sheet by the angle in degrees, from -90 to 90,
+// e.g. 45 for angled column headers. An angle of 0 resets the rotation.
+func (sheet *Sheet) SetTextRotation(gridRange GridRange, angle int) (err error) {
+	err = sheet.SetTextRotationContext(context.Background(), gridRange, angle)
+	return
+}
+
+// SetTextRotationContext is like SetTextRotation with the context of the request.
+func (sheet *Sheet) SetTextRotationContext(ctx context.Context, gridRange GridRange, angle int) (err error) {
+	format := CellFormat{TextRotation: &TextRotation{Angle: angle}}
+	err = sheet.Spreadsheet.service.SetCellFormatContext(ctx, sheet, gridRange, format, "textRotation")
+	return
+}
+
+// SetVerticalText stacks the characters of the text of the cells in the range of the sheet top to bottom.
+func (sheet *Sheet) SetVerticalText(gridRange GridRange) (err error) {
+	err = sheet.SetVerticalTextContext(context.Background(), gridRange)
+	return
+}
+
+// SetVerticalTextContext is like SetVerticalText with the context of the request.
+func (sheet *Sheet) SetVerticalTextContext(ctx context.Context, gridRange GridRange) (err error) {
+	format := CellFormat{TextRotation: &TextRotation{Vertical: true}}
+	err = sheet.Spreadsheet.service.SetCellFormatContext(ctx, sheet, gridRange, format, "textRotation")
+	return
+}
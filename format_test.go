@@ -0,0 +1,134 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFormatTestSheet returns a sheet whose batch updates are decoded into the last repeatCell request.
+func newFormatTestSheet(t *testing.T, repeatCell *map[string]interface{}) (sheet *Sheet, server *httptest.Server) {
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Requests []struct {
+				RepeatCell map[string]interface{} `json:"repeatCell"`
+			} `json:"requests"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		*repeatCell = body.Requests[0].RepeatCell
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	spreadsheet := &Spreadsheet{ID: "abc", service: s, Sheets: []Sheet{{Properties: SheetProperties{ID: 7, Title: "Report"}}}}
+	sheet = &spreadsheet.Sheets[0]
+	sheet.Spreadsheet = spreadsheet
+	return
+}
+
+func TestSetNumberFormat(t *testing.T) {
+	assert := assert.New(t)
+	var repeatCell map[string]interface{}
+	sheet, server := newFormatTestSheet(t, &repeatCell)
+	defer server.Close()
+
+	assert.NoError(sheet.SetNumberFormat(GridRange{StartColumnIndex: 2, EndColumnIndex: 3},
+		NumberFormat{Type: NumberFormatCurrency, Pattern: "#,##0.00 [$€]"}))
+	assert.Equal(map[string]interface{}{
+		"range": map[string]interface{}{"sheetId": 7.0, "startColumnIndex": 2.0, "endColumnIndex": 3.0},
+		"cell": map[string]interface{}{"userEnteredFormat": map[string]interface{}{
+			"numberFormat": map[string]interface{}{"type": "CURRENCY", "pattern": "#,##0.00 [$€]"},
+		}},
+		"fields": "userEnteredFormat.numberFormat",
+	}, repeatCell)
+	assert.Error(sheet.SetNumberFormat(GridRange{}, NumberFormat{Pattern: "0.0"}))
+	assert.Equal("userEnteredFormat.a,userEnteredFormat.b.c", prefixFields("userEnteredFormat.", "a,b.c"))
+}
+
+func TestSetTextFormat(t *testing.T) {
+	assert := assert.New(t)
+	var repeatCell map[string]interface{}
+	sheet, server := newFormatTestSheet(t, &repeatCell)
+	defer server.Close()
+	header := GridRange{EndRowIndex: 1}
+	format := func() interface{} {
+		return repeatCell["cell"].(map[string]interface{})["userEnteredFormat"].(map[string]interface{})["textFormat"]
+	}
+
+	assert.NoError(sheet.SetTextFormat(header, TextFormat{Bold: true, FontFamily: "Roboto", FontSize: 12}))
+	assert.Equal("userEnteredFormat.textFormat", repeatCell["fields"])
+	assert.Equal(map[string]interface{}{"bold": true, "fontFamily": "Roboto", "fontSize": 12.0}, format())
+
+	assert.NoError(sheet.SetBold(header, false))
+	assert.Equal("userEnteredFormat.textFormat.bold", repeatCell["fields"])
+	assert.Equal(map[string]interface{}{}, format())
+
+	assert.NoError(sheet.SetFontSize(header, 14))
+	assert.Equal("userEnteredFormat.textFormat.fontSize", repeatCell["fields"])
+	assert.Equal(map[string]interface{}{"fontSize": 14.0}, format())
+
+	assert.NoError(sheet.SetForegroundColor(header, Color{Red: 1, Alpha: 1}))
+	assert.Equal("userEnteredFormat.textFormat.foregroundColor", repeatCell["fields"])
+	assert.Equal(map[string]interface{}{"foregroundColor": map[string]interface{}{"red": 1.0, "green": 0.0, "blue": 0.0, "alpha": 1.0}}, format())
+}
+
+func TestSetBackgroundColor(t *testing.T) {
+	assert := assert.New(t)
+	var repeatCell map[string]interface{}
+	sheet, server := newFormatTestSheet(t, &repeatCell)
+	defer server.Close()
+
+	assert.NoError(sheet.SetBackgroundColor(GridRange{StartRowIndex: 3, EndRowIndex: 4}, RGB(255, 0, 0)))
+	assert.Equal("userEnteredFormat.backgroundColor", repeatCell["fields"])
+	assert.Equal(map[string]interface{}{"userEnteredFormat": map[string]interface{}{
+		"backgroundColor": map[string]interface{}{"red": 1.0, "green": 0.0, "blue": 0.0, "alpha": 1.0},
+	}}, repeatCell["cell"])
+}
+
+func TestSetAlignmentAndWrapStrategy(t *testing.T) {
+	assert := assert.New(t)
+	var repeatCell map[string]interface{}
+	sheet, server := newFormatTestSheet(t, &repeatCell)
+	defer server.Close()
+	header := GridRange{EndRowIndex: 1}
+
+	assert.NoError(sheet.SetAlignment(header, HorizontalAlignCenter, VerticalAlignMiddle))
+	assert.Equal("userEnteredFormat.horizontalAlignment,userEnteredFormat.verticalAlignment", repeatCell["fields"])
+	assert.Equal(map[string]interface{}{"userEnteredFormat": map[string]interface{}{
+		"horizontalAlignment": "CENTER",
+		"verticalAlignment":   "MIDDLE",
+	}}, repeatCell["cell"])
+
+	assert.NoError(sheet.SetWrapStrategy(header, WrapWrap))
+	assert.Equal("userEnteredFormat.wrapStrategy", repeatCell["fields"])
+	assert.Equal(map[string]interface{}{"userEnteredFormat": map[string]interface{}{"wrapStrategy": "WRAP"}}, repeatCell["cell"])
+
+	assert.EqualError(sheet.SetAlignment(header, "MIDDLE", ""), `invalid HorizontalAlignment: "MIDDLE"`)
+	assert.Error(sheet.SetWrapStrategy(header, "WRAP_TEXT"))
+}
+
+func TestSetTextRotation(t *testing.T) {
+	assert := assert.New(t)
+	var repeatCell map[string]interface{}
+	sheet, server := newFormatTestSheet(t, &repeatCell)
+	defer server.Close()
+	header := GridRange{EndRowIndex: 1}
+
+	assert.NoError(sheet.SetTextRotation(header, 45))
+	assert.Equal("userEnteredFormat.textRotation", repeatCell["fields"])
+	assert.Equal(map[string]interface{}{"userEnteredFormat": map[string]interface{}{
+		"textRotation": map[string]interface{}{"angle": 45.0},
+	}}, repeatCell["cell"])
+
+	assert.NoError(sheet.SetVerticalText(header))
+	assert.Equal(map[string]interface{}{"userEnteredFormat": map[string]interface{}{
+		"textRotation": map[string]interface{}{"vertical": true},
+	}}, repeatCell["cell"])
+
+	assert.EqualError(sheet.SetTextRotation(header, 120), "invalid text rotation angle: 120")
+	err := sheet.Spreadsheet.service.SetCellFormat(sheet, header, CellFormat{TextRotation: &TextRotation{Angle: 30, Vertical: true}}, "textRotation")
+	assert.Error(err)
+}
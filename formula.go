@@ -0,0 +1,247 @@
+package spreadsheet
+
+import (
+	"strings"
+	"unicode"
+)
+
+// commaDecimalLanguages are the languages whose locales use a comma as the
+// decimal separator, and therefore a semicolon to separate arguments.
+var commaDecimalLanguages = map[string]bool{
+	"cs": true, "da": true, "de": true, "es": true, "fi": true, "fr": true,
+	"id": true, "it": true, "nb": true, "nl": true, "pl": true, "pt": true,
+	"ru": true, "sv": true, "tr": true, "uk": true,
+}
+
+// localizedFunctions maps canonical function names to their localized names by language.
+var localizedFunctions = map[string]map[string]string{
+	"de": {
+		"SUM": "SUMME", "IF": "WENN", "AVERAGE": "MITTELWERT", "COUNT": "ANZAHL",
+		"COUNTIF": "ZÄHLENWENN", "SUMIF": "SUMMEWENN", "ROUND": "RUNDEN",
+		"TODAY": "HEUTE", "NOW": "JETZT", "AND": "UND", "OR": "ODER", "NOT": "NICHT",
+		"VLOOKUP": "SVERWEIS", "HLOOKUP": "WVERWEIS", "CONCATENATE": "VERKETTEN",
+		"TRUE": "WAHR", "FALSE": "FALSCH",
+	},
+	"fr": {
+		"SUM": "SOMME", "IF": "SI", "AVERAGE": "MOYENNE", "COUNT": "NB",
+		"COUNTIF": "NB.SI", "SUMIF": "SOMME.SI", "ROUND": "ARRONDI",
+		"TODAY": "AUJOURDHUI", "NOW": "MAINTENANT", "AND": "ET", "OR": "OU", "NOT": "NON",
+		"VLOOKUP": "RECHERCHEV", "HLOOKUP": "RECHERCHEH", "CONCATENATE": "CONCATENER",
+		"TRUE": "VRAI", "FALSE": "FAUX",
+	},
+	"es": {
+		"SUM": "SUMA", "IF": "SI", "AVERAGE": "PROMEDIO", "COUNT": "CONTAR",
+		"COUNTIF": "CONTAR.SI", "SUMIF": "SUMAR.SI", "ROUND": "REDONDEAR",
+		"TODAY": "HOY", "NOW": "AHORA", "AND": "Y", "OR": "O", "NOT": "NO",
+		"VLOOKUP": "BUSCARV", "HLOOKUP": "BUSCARH", "CONCATENATE": "CONCATENAR",
+		"TRUE": "VERDADERO", "FALSE": "FALSO",
+	},
+	"it": {
+		"SUM": "SOMMA", "IF": "SE", "AVERAGE": "MEDIA", "COUNT": "CONTA.NUMERI",
+		"COUNTIF": "CONTA.SE", "SUMIF": "SOMMA.SE", "ROUND": "ARROTONDA",
+		"TODAY": "OGGI", "NOW": "ADESSO", "AND": "E", "OR": "O", "NOT": "NON",
+		"VLOOKUP": "CERCA.VERT", "HLOOKUP": "CERCA.ORIZZ", "CONCATENATE": "CONCATENA",
+		"TRUE": "VERO", "FALSE": "FALSO",
+	},
+	"pt": {
+		"SUM": "SOMA", "IF": "SE", "AVERAGE": "MÉDIA", "COUNT": "CONT.NÚM",
+		"COUNTIF": "CONT.SE", "SUMIF": "SOMASE", "ROUND": "ARRED",
+		"TODAY": "HOJE", "NOW": "AGORA", "AND": "E", "OR": "OU", "NOT": "NÃO",
+		"VLOOKUP": "PROCV", "HLOOKUP": "PROCH", "CONCATENATE": "CONCATENAR",
+		"TRUE": "VERDADEIRO", "FALSE": "FALSO",
+	},
+}
+
+// FormulaTranslator converts formulas between the canonical en_US syntax and
+// the syntax of a locale: function names, argument separators, array literal
+// separators and decimal separators.
+type FormulaTranslator struct {
+	commaDecimal bool
+	functions    map[string]string
+	canonical    map[string]string
+}
+
+// NewFormulaTranslator returns a translator for the locale like "de_DE".
+func NewFormulaTranslator(locale string) *FormulaTranslator {
+	language := strings.ToLower(strings.SplitN(strings.Replace(locale, "-", "_", -1), "_", 2)[0])
+	t := &FormulaTranslator{
+		commaDecimal: commaDecimalLanguages[language],
+		functions:    map[string]string{},
+		canonical:    map[string]string{},
+	}
+	for name, localized := range localizedFunctions[language] {
+		t.AddFunction(name, localized)
+	}
+	return t
+}
+
+// AddFunction registers the localized name of a function.
+func (t *FormulaTranslator) AddFunction(canonical, localized string) {
+	canonical, localized = strings.ToUpper(canonical), strings.ToUpper(localized)
+	t.functions[canonical] = localized
+	t.canonical[localized] = canonical
+}
+
+// ToLocale converts the canonical formula to the syntax of the locale.
+// Values which are not formulas are returned as-is.
+func (t *FormulaTranslator) ToLocale(formula string) string {
+	return t.translate(formula, t.functions, true)
+}
+
+// FromLocale converts the formula in the syntax of the locale to the canonical syntax.
+// Values which are not formulas are returned as-is.
+func (t *FormulaTranslator) FromLocale(formula string) string {
+	return t.translate(formula, t.canonical, false)
+}
+
+func (t *FormulaTranslator) translate(formula string, functions map[string]string, toLocale bool) string {
+	if !strings.HasPrefix(formula, "=") {
+		return formula
+	}
+	src := []rune(formula)
+	var b strings.Builder
+	b.Grow(len(formula))
+	braces := 0
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '"' || c == '\'':
+			// string literals and quoted sheet names are copied verbatim
+			j := i + 1
+			for j < len(src) {
+				if src[j] == c {
+					if j+1 < len(src) && src[j+1] == c {
+						j += 2
+						continue
+					}
+					break
+				}
+				j++
+			}
+			if j >= len(src) {
+				j = len(src) - 1
+			}
+			b.WriteString(string(src[i : j+1]))
+			i = j
+		case isIdentifierStart(c):
+			j := i
+			for j < len(src) && isIdentifierPart(src[j]) {
+				j++
+			}
+			name := string(src[i:j])
+			if translated, ok := functions[strings.ToUpper(name)]; ok && j < len(src) && src[j] == '(' {
+				name = translated
+			}
+			b.WriteString(name)
+			i = j - 1
+		case unicode.IsDigit(c):
+			j := i
+			for j < len(src) && (unicode.IsDigit(src[j]) || src[j] == '.' || src[j] == ',') {
+				if src[j] != '.' && src[j] != ',' {
+					j++
+					continue
+				}
+				if !t.commaDecimal || !t.isDecimalSeparator(src, j, toLocale) {
+					break
+				}
+				j++
+			}
+			number := string(src[i:j])
+			if t.commaDecimal {
+				if toLocale {
+					number = strings.Replace(number, ".", ",", -1)
+				} else {
+					number = strings.Replace(number, ",", ".", -1)
+				}
+			}
+			b.WriteString(number)
+			i = j - 1
+		case c == '{':
+			braces++
+			b.WriteRune(c)
+		case c == '}':
+			braces--
+			b.WriteRune(c)
+		default:
+			b.WriteRune(t.separator(c, braces > 0, toLocale))
+		}
+	}
+	return b.String()
+}
+
+// isDecimalSeparator reports whether the rune at i is the decimal separator of a number.
+func (t *FormulaTranslator) isDecimalSeparator(src []rune, i int, toLocale bool) bool {
+	sep := '.'
+	if !toLocale {
+		sep = ','
+	}
+	return src[i] == sep && i+1 < len(src) && unicode.IsDigit(src[i+1])
+}
+
+func (t *FormulaTranslator) separator(c rune, inArray, toLocale bool) rune {
+	if !t.commaDecimal {
+		return c
+	}
+	if toLocale {
+		switch {
+		case c == ',' && inArray:
+			return '\\'
+		case c == ',':
+			return ';'
+		}
+		return c
+	}
+	switch {
+	case c == '\\' && inArray:
+		return ','
+	case c == ';' && !inArray:
+		return ','
+	}
+	return c
+}
+
+func isIdentifierStart(c rune) bool {
+	return unicode.IsLetter(c) || c == '_' || c == '$'
+}
+
+func isIdentifierPart(c rune) bool {
+	return isIdentifierStart(c) || unicode.IsDigit(c) || c == '.' || c == ':' || c == '!'
+}
+
+// FormulaTranslator returns a translator for the locale of the spreadsheet.
+func (spreadsheet *Spreadsheet) FormulaTranslator() *FormulaTranslator {
+	return NewFormulaTranslator(spreadsheet.Properties.Locale)
+}
+
+// SetFormulaTranslation sets whether formulas are translated to the locale of
+// the spreadsheet when sheets are synchronized, and back to the canonical
+// syntax when spreadsheets are fetched.
+func (s *Service) SetFormulaTranslation(enabled bool) {
+	s.translateFormulas = enabled
+}
+
+// translateFormulasFromLocale converts the formulas entered by users to the canonical syntax,
+// in the grid data and in the cells made from it.
+func translateFormulasFromLocale(spreadsheet *Spreadsheet) {
+	t := spreadsheet.FormulaTranslator()
+	for i := range spreadsheet.Sheets {
+		// the cells and the columns share the values entered, which are translated once by row
+		for _, row := range spreadsheet.Sheets[i].Rows {
+			for j := range row {
+				if value := row[j].UserEnteredValue; value != nil && value.FormulaValue != "" {
+					value.FormulaValue = t.FromLocale(value.FormulaValue)
+				}
+			}
+		}
+		for _, gridData := range spreadsheet.Sheets[i].Data.GridData {
+			for _, row := range gridData.RowData {
+				for j := range row.Values {
+					value := &row.Values[j].UserEnteredValue
+					if value.FormulaValue != "" {
+						value.FormulaValue = t.FromLocale(value.FormulaValue)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package spreadsheet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormulaTranslator(t *testing.T) {
+	assert := assert.New(t)
+	tr := NewFormulaTranslator("de_DE")
+	cases := map[string]string{
+		`=SUM(A1:B2,1.5)`:               `=SUMME(A1:B2;1,5)`,
+		`=IF(A1>0.5,"a, b.c",'x,y'!A1)`: `=WENN(A1>0,5;"a, b.c";'x,y'!A1)`,
+		`={1,2;3.5,4}`:                  `={1\2;3,5\4}`,
+		`=sum(Sheet1!A:A)`:              `=SUMME(Sheet1!A:A)`,
+		`=MYFUNC(A1,2)`:                 `=MYFUNC(A1;2)`,
+		`1.5, not a formula`:            `1.5, not a formula`,
+	}
+	for canonical, localized := range cases {
+		assert.Equal(localized, tr.ToLocale(canonical), canonical)
+	}
+	assert.Equal(`=SUM(A1:B2,1.5)`, tr.FromLocale(`=SUMME(A1:B2;1,5)`))
+	assert.Equal(`=IF(A1>0.5,"a; b",TRUE())`, tr.FromLocale(`=WENN(A1>0,5;"a; b";WAHR())`))
+	assert.Equal(`={1,2;3.5,4}`, tr.FromLocale(`={1\2;3,5\4}`))
+
+	tr = NewFormulaTranslator("fr-FR")
+	tr.AddFunction("MYFUNC", "MAFONC")
+	assert.Equal(`=NB.SI(A:A;"x")+MAFONC(1)`, tr.ToLocale(`=COUNTIF(A:A,"x")+MYFUNC(1)`))
+	assert.Equal(`=COUNTIF(A:A,"x")+MYFUNC(1)`, tr.FromLocale(`=NB.SI(A:A;"x")+MAFONC(1)`))
+}
+
+func TestFormulaTranslatorCanonicalLocale(t *testing.T) {
+	assert := assert.New(t)
+	tr := NewFormulaTranslator("en_US")
+	assert.Equal(`=SUM(A1,1.5)`, tr.ToLocale(`=SUM(A1,1.5)`))
+	assert.Equal(`=SUM(A1,1.5)`, tr.FromLocale(`=SUM(A1,1.5)`))
+}
+
+func TestFetchSpreadsheetTranslatesFormulas(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"spreadsheetId": "abc", "properties": {"locale": "de_DE"}, "sheets": [{
+			"properties": {"sheetId": 1, "title": "one"},
+			"data": [{"rowData": [{"values": [{"formattedValue": "4,5", "userEnteredValue": {"formulaValue": "=SUMME(A2;2,5)"}}]}]}]
+		}]}`))
+	}))
+	defer server.Close()
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	s.SetFormulaTranslation(true)
+
+	for _, sparse := range []bool{false, true} {
+		var opts []CallOption
+		if sparse {
+			opts = append(opts, WithSparseCells())
+		}
+		spreadsheet, err := s.FetchSpreadsheet("abc", opts...)
+		if !assert.NoError(err) {
+			continue
+		}
+		sheet := &spreadsheet.Sheets[0]
+		assert.Equal("=SUM(A2,2.5)", sheet.Data.GridData[0].RowData[0].Values[0].UserEnteredValue.FormulaValue)
+		formula, ok := sheet.Rows[0][0].FormulaValue()
+		assert.True(ok)
+		assert.Equal("=SUM(A2,2.5)", formula)
+		formula, _ = sheet.Columns[0][0].FormulaValue()
+		assert.Equal("=SUM(A2,2.5)", formula)
+	}
+}
@@ -0,0 +1,32 @@
+package spreadsheet
+
+// GridRange is a range on a sheet. Indexes are zero-based, the start is
+// inclusive and the end is exclusive. Unset indexes mean the range is unbounded on that side.
+type GridRange struct {
+	SheetID          uint `json:"sheetId"`
+	StartRowIndex    uint `json:"startRowIndex,omitempty"`
+	EndRowIndex      uint `json:"endRowIndex,omitempty"`
+	StartColumnIndex uint `json:"startColumnIndex,omitempty"`
+	EndColumnIndex   uint `json:"endColumnIndex,omitempty"`
+}
+
+// Contains reports whether the zero based row and column are in the range.
+func (gridRange GridRange) Contains(row, column uint) bool {
+	return row >= gridRange.StartRowIndex && (gridRange.EndRowIndex == 0 || row < gridRange.EndRowIndex) &&
+		column >= gridRange.StartColumnIndex && (gridRange.EndColumnIndex == 0 || column < gridRange.EndColumnIndex)
+}
+
+// GridCoordinate is a coordinate on a sheet. Indexes are zero-based.
+type GridCoordinate struct {
+	SheetID     uint `json:"sheetId"`
+	RowIndex    uint `json:"rowIndex"`
+	ColumnIndex uint `json:"columnIndex"`
+}
+
+// DimensionRange is a range along a single dimension on a sheet.
+type DimensionRange struct {
+	SheetID    uint      `json:"sheetId"`
+	Dimension  Dimension `json:"dimension"`
+	StartIndex uint      `json:"startIndex,omitempty"`
+	EndIndex   uint      `json:"endIndex,omitempty"`
+}
@@ -0,0 +1,76 @@
+package spreadsheet
+
+import "time"
+
+// SyncResult describes a synchronization of a sheet.
+// It is also reported when the synchronization fails part way.
+type SyncResult struct {
+	// CellsUpdated is the number of modified cells sent to the server.
+	CellsUpdated int
+	// RangesSent is the number of value ranges sent to the server.
+	RangesSent int
+	// APICalls is the number of API calls made.
+	APICalls int
+	// BytesTransferred is the size of the request and response bodies.
+	BytesTransferred int
+	// Duration is the time taken by the synchronization.
+	Duration time.Duration
+}
+
+// SyncHooks are callbacks invoked around every synchronization of a sheet.
+// Any of the callbacks may be nil.
+type SyncHooks struct {
+	// BeforeSync is called before the changes are sent.
+	// Returning an error aborts the synchronization.
+	BeforeSync func(sheet *Sheet) error
+	// AfterSync is called after the changes are successfully sent.
+	AfterSync func(sheet *Sheet, result SyncResult)
+	// OnError is called when the synchronization fails.
+	OnError func(sheet *Sheet, err error)
+}
+
+// AddSyncHooks registers hooks invoked when any sheet is synchronized by the service.
+func (s *Service) AddSyncHooks(hooks SyncHooks) {
+	s.syncHooks = append(s.syncHooks, hooks)
+}
+
+// AddSyncHooks registers hooks invoked when the sheet is synchronized.
+// They are invoked after the hooks registered on the service.
+func (sheet *Sheet) AddSyncHooks(hooks SyncHooks) {
+	sheet.syncHooks = append(sheet.syncHooks, hooks)
+}
+
+func (s *Service) allSyncHooks(sheet *Sheet) []SyncHooks {
+	hooks := make([]SyncHooks, 0, len(s.syncHooks)+len(sheet.syncHooks))
+	hooks = append(hooks, s.syncHooks...)
+	return append(hooks, sheet.syncHooks...)
+}
+
+func (s *Service) beforeSync(sheet *Sheet) (err error) {
+	for _, hooks := range s.allSyncHooks(sheet) {
+		if hooks.BeforeSync == nil {
+			continue
+		}
+		err = hooks.BeforeSync(sheet)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (s *Service) afterSync(sheet *Sheet, result SyncResult) {
+	for _, hooks := range s.allSyncHooks(sheet) {
+		if hooks.AfterSync != nil {
+			hooks.AfterSync(sheet, result)
+		}
+	}
+}
+
+func (s *Service) onSyncError(sheet *Sheet, err error) {
+	for _, hooks := range s.allSyncHooks(sheet) {
+		if hooks.OnError != nil {
+			hooks.OnError(sheet, err)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package spreadsheet
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncHooks(t *testing.T) {
+	assert := assert.New(t)
+	s := &Service{}
+	sheet := &Sheet{}
+	calls := []string{}
+	s.AddSyncHooks(SyncHooks{
+		BeforeSync: func(*Sheet) error {
+			calls = append(calls, "service before")
+			return nil
+		},
+		AfterSync: func(_ *Sheet, result SyncResult) {
+			calls = append(calls, "service after")
+		},
+	})
+	sheet.AddSyncHooks(SyncHooks{
+		BeforeSync: func(*Sheet) error {
+			calls = append(calls, "sheet before")
+			return errors.New("abort")
+		},
+		OnError: func(_ *Sheet, err error) {
+			calls = append(calls, "sheet error: "+err.Error())
+		},
+	})
+
+	_, err := s.SyncSheet(sheet)
+	assert.EqualError(err, "abort")
+	assert.Equal([]string{"service before", "sheet before", "sheet error: abort"}, calls)
+
+	calls = calls[:0]
+	s.afterSync(sheet, SyncResult{})
+	assert.Equal([]string{"service after"}, calls)
+}
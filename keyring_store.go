@@ -0,0 +1,107 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// KeyringTokenStore keeps the token in the keyring of the OS instead of a plain text file:
+// the keychain of the user on macOS, through the security command, and the Secret Service
+// (GNOME Keyring, KWallet) on Linux, through the secret-tool command of libsecret.
+// Other OSes, e.g. Windows, are not supported: Load and Save return ErrKeyringUnsupported.
+// The token is passed to the commands through their standard input, never as an argument.
+type KeyringTokenStore struct {
+	// Service names the application the token belongs to, e.g. "mytool".
+	Service string
+	// Account names the user of the token within the service.
+	Account string
+}
+
+// ErrKeyringUnsupported is returned by KeyringTokenStore on OSes without a supported keyring.
+var ErrKeyringUnsupported = errors.New("keyring is not supported on this OS")
+
+// keyringOS is the OS whose keyring is used. Tests replace it.
+var keyringOS = runtime.GOOS
+
+// keyringNotFound is the exit status of the keyring commands when no secret matches.
+var keyringNotFound = map[string]int{
+	"darwin": 44,
+	"linux":  1,
+}
+
+// runKeyringCommand runs the command with the input and returns its output and its exit status.
+// Tests replace it.
+var runKeyringCommand = func(input string, name string, args ...string) (output string, status int, err error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err = cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		status = exitErr.ExitCode()
+		err = fmt.Errorf("%s: %v: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	output = stdout.String()
+	return
+}
+
+// Load reads the token from the keyring.
+func (s KeyringTokenStore) Load() (token *oauth2.Token, err error) {
+	var name string
+	var args []string
+	switch keyringOS {
+	case "darwin":
+		name, args = "security", []string{"find-generic-password", "-s", s.Service, "-a", s.Account, "-w"}
+	case "linux":
+		name, args = "secret-tool", []string{"lookup", "service", s.Service, "account", s.Account}
+	default:
+		err = ErrKeyringUnsupported
+		return
+	}
+	output, status, err := runKeyringCommand("", name, args...)
+	if err != nil && status == keyringNotFound[keyringOS] && strings.TrimSpace(output) == "" {
+		err = ErrNoToken
+		return
+	}
+	if err != nil {
+		return
+	}
+	token = &oauth2.Token{}
+	err = json.Unmarshal([]byte(strings.TrimSpace(output)), token)
+	return
+}
+
+// Save writes the token to the keyring, replacing the token saved before.
+func (s KeyringTokenStore) Save(token *oauth2.Token) (err error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return
+	}
+	switch keyringOS {
+	case "darwin":
+		// security reads the command from its standard input with -i, so that the token
+		// doesn't show in the arguments of the process
+		command := fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n",
+			securityQuote(s.Service), securityQuote(s.Account), hex.EncodeToString(data))
+		_, _, err = runKeyringCommand(command, "security", "-i")
+	case "linux":
+		_, _, err = runKeyringCommand(string(data), "secret-tool", "store",
+			"--label", s.Service+" OAuth token", "service", s.Service, "account", s.Account)
+	default:
+		err = ErrKeyringUnsupported
+	}
+	return
+}
+
+// securityQuote quotes the argument of a command read by security -i.
+func securityQuote(arg string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
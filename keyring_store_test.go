@@ -0,0 +1,61 @@
+package spreadsheet
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestKeyringTokenStore(t *testing.T) {
+	run, goos := runKeyringCommand, keyringOS
+	defer func() {
+		runKeyringCommand, keyringOS = run, goos
+	}()
+	token := &oauth2.Token{AccessToken: "access", RefreshToken: "refresh"}
+
+	for _, keyringOS = range []string{"darwin", "linux"} {
+		assert := assert.New(t)
+		secrets := map[string]string{}
+		runKeyringCommand = func(input string, name string, args ...string) (string, int, error) {
+			for _, arg := range args {
+				assert.NotContains(arg, "refresh", "the token must not be an argument")
+			}
+			switch {
+			case args[0] == "find-generic-password" || args[0] == "lookup":
+				if secret, ok := secrets[name]; ok {
+					return secret + "\n", 0, nil
+				}
+				return "", keyringNotFound[keyringOS], errors.New("not found")
+			case args[0] == "-i":
+				fields := strings.Fields(input)
+				assert.Equal([]string{"add-generic-password", "-U", "-s", `"spreadsheet-test"`, "-a", `"user"`, "-X"}, fields[:7])
+				secret, err := hex.DecodeString(fields[7])
+				assert.NoError(err)
+				secrets[name] = string(secret)
+			case args[0] == "store":
+				secrets[name] = input
+			}
+			return "", 0, nil
+		}
+
+		store := KeyringTokenStore{Service: "spreadsheet-test", Account: "user"}
+		_, err := store.Load()
+		assert.Equal(ErrNoToken, err, keyringOS)
+
+		assert.NoError(store.Save(token), keyringOS)
+		loaded, err := store.Load()
+		assert.NoError(err, keyringOS)
+		assert.Equal(token, loaded, keyringOS)
+	}
+
+	keyringOS = "windows"
+	store := KeyringTokenStore{Service: "spreadsheet-test", Account: "user"}
+	_, err := store.Load()
+	assert.Equal(t, ErrKeyringUnsupported, err)
+	assert.Equal(t, ErrKeyringUnsupported, store.Save(token))
+	assert.Equal(t, `"a \"b\" \\c"`, securityQuote(`a "b" \c`))
+}
@@ -0,0 +1,50 @@
+package spreadsheet
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+// Logger logs the calls of a service. *log.Logger implements it.
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+// WithLogger logs the method, the path, the status and the latency of every call of the service.
+func WithLogger(logger Logger) ServiceOption {
+	return func(s *serviceSettings) {
+		s.logger = logger
+	}
+}
+
+// WithDebug also logs the bodies of the requests and of the responses.
+// The calls are logged to the standard error unless WithLogger is given.
+func WithDebug() ServiceOption {
+	return func(s *serviceSettings) {
+		s.debug = true
+	}
+}
+
+// debugLogger is the logger of WithDebug without WithLogger.
+var debugLogger Logger = log.New(os.Stderr, "spreadsheet: ", log.LstdFlags)
+
+// logCall logs the call to the logger of the service, if any.
+func (s *Service) logCall(method, path string, reqBody []byte, status int, body []byte, d time.Duration, err error) {
+	logger := s.logger
+	if logger == nil {
+		if !s.debug {
+			return
+		}
+		logger = debugLogger
+	}
+	latency := d.Round(time.Millisecond)
+	switch {
+	case err != nil:
+		logger.Printf("%s %s error (%s): %v", method, path, latency, err)
+	case s.debug:
+		logger.Printf("%s %s %d (%s)\nrequest: %s\nresponse: %s", method, path, status, latency, reqBody, body)
+	default:
+		logger.Printf("%s %s %d (%s)", method, path, status, latency)
+	}
+}
@@ -0,0 +1,43 @@
+package spreadsheet
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLogger struct {
+	lines []string
+}
+
+func (l *testLogger) Printf(format string, v ...interface{}) {
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func TestLogger(t *testing.T) {
+	assert := assert.New(t)
+	client := &http.Client{Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, `{"updatedCells":1}`), nil
+	})}
+
+	logger := &testLogger{}
+	s := NewServiceWithClient(client, WithLogger(logger))
+	_, err := s.UpdateValues("abc", "A1", [][]interface{}{{1}})
+	assert.NoError(err)
+	if assert.Len(logger.lines, 1) {
+		assert.Regexp(regexp.MustCompile(`^PUT /v4/spreadsheets/abc/values/A1\?valueInputOption=USER_ENTERED 200 \(\d+m?s\)$`), logger.lines[0])
+	}
+
+	logger = &testLogger{}
+	s = NewServiceWithClient(client, WithLogger(logger), WithDebug())
+	_, err = s.UpdateValues("abc", "A1", [][]interface{}{{1}})
+	assert.NoError(err)
+	if assert.Len(logger.lines, 1) {
+		assert.Regexp(regexp.MustCompile(`(?s)^PUT /v4/spreadsheets/abc/values/A1\?valueInputOption=USER_ENTERED 200 \(.+\)\n`+
+			`request: \{"majorDimension":"ROWS","range":"A1","values":\[\[1\]\]\}\n`+
+			`response: \{"updatedCells":1\}$`), logger.lines[0])
+	}
+}
@@ -0,0 +1,124 @@
+package spreadsheet
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"runtime"
+
+	"golang.org/x/oauth2"
+)
+
+// WithLoopbackFlow makes NewServiceForCLI authorize the user in the browser, opened automatically,
+// which redirects to a server listening on 127.0.0.1 to hand over the authorization code.
+// It replaces the copy and paste of the code, deprecated by Google.
+func WithLoopbackFlow() ServiceOption {
+	return func(s *serviceSettings) {
+		s.authFlow = loopbackFlow
+	}
+}
+
+// openBrowser opens the URL in the browser of the user. Tests replace it.
+var openBrowser = func(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
+
+// loopbackFlow gets the authorization code through a redirect to a local server,
+// with PKCE so that other local programs catching the code can't exchange it.
+func loopbackFlow(ctx context.Context, config *oauth2.Config) (tok *oauth2.Token, err error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to listen for the redirect: %v", err)
+	}
+	defer listener.Close()
+	state, err := randomString()
+	if err != nil {
+		return
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	c := *config
+	c.RedirectURL = fmt.Sprintf("http://%s/", listener.Addr())
+	authURL := c.AuthCodeURL(state, oauth2.AccessTypeOffline,
+		oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:])),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"))
+	fmt.Printf("Go to the following link in your browser if it doesn't open: \n%v\n", authURL)
+	openBrowser(authURL)
+
+	code, err := receiveCode(ctx, listener, state)
+	if err != nil {
+		return
+	}
+	tok, err = c.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", verifier))
+	if err != nil {
+		return nil, fmt.Errorf("Unable to retrieve token from web: %v", err)
+	}
+	return
+}
+
+// receiveCode serves the redirect on the listener until it brings the authorization code of the state.
+func receiveCode(ctx context.Context, listener net.Listener, state string) (code string, err error) {
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("state") != state {
+			http.Error(w, "Invalid state.", http.StatusBadRequest)
+			return
+		}
+		res := result{code: query.Get("code")}
+		if e := query.Get("error"); e != "" || res.code == "" {
+			res.err = fmt.Errorf("Unable to retrieve authorization code: %s", e)
+			fmt.Fprintln(w, "Authorization failed, you can close this window.")
+		} else {
+			fmt.Fprintln(w, "Authorization succeeded, you can close this window.")
+		}
+		select {
+		case results <- res:
+		default:
+		}
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	select {
+	case <-ctx.Done():
+		err = ctx.Err()
+	case res := <-results:
+		code, err = res.code, res.err
+	}
+	return
+}
+
+// randomString returns 32 random bytes encoded for URLs.
+func randomString() (s string, err error) {
+	b := make([]byte, 32)
+	if _, err = rand.Read(b); err != nil {
+		err = errors.New("Unable to generate random state: " + err.Error())
+		return
+	}
+	s = base64.RawURLEncoding.EncodeToString(b)
+	return
+}
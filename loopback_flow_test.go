@@ -0,0 +1,55 @@
+package spreadsheet
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiveCode(t *testing.T) {
+	assert := assert.New(t)
+
+	redirect := func(query string) (code string, err error) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if !assert.NoError(err) {
+			return
+		}
+		defer listener.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		go func() {
+			resp, err := http.Get("http://" + listener.Addr().String() + "/?state=other")
+			if assert.NoError(err) {
+				assert.Equal(http.StatusBadRequest, resp.StatusCode)
+				resp.Body.Close()
+			}
+			resp, err = http.Get("http://" + listener.Addr().String() + "/?" + query)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return receiveCode(ctx, listener, "state")
+	}
+
+	code, err := redirect("state=state&code=abc")
+	assert.NoError(err)
+	assert.Equal("abc", code)
+
+	_, err = redirect("state=state&error=access_denied")
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "access_denied")
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if assert.NoError(err) {
+		defer listener.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		defer cancel()
+		_, err = receiveCode(ctx, listener, "state")
+		assert.Equal(context.DeadlineExceeded, err)
+	}
+}
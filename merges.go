@@ -0,0 +1,27 @@
+package spreadsheet
+
+// Merges returns the ranges of the merged cells of the sheet as of when it was fetched.
+func (sheet *Sheet) Merges() []GridRange {
+	return append([]GridRange(nil), sheet.merges...)
+}
+
+// MergeAt returns the range of the merged cells containing the zero based row and column, if any.
+// The value of a merge is the value of its top left cell, see Sheet.Cell.
+func (sheet *Sheet) MergeAt(row, column int) (merge GridRange, ok bool) {
+	if row < 0 || column < 0 {
+		return
+	}
+	for _, m := range sheet.merges {
+		if m.Contains(uint(row), uint(column)) {
+			merge, ok = m, true
+			return
+		}
+	}
+	return
+}
+
+// IsMerged reports whether the cell at the zero based row and column is in a merge.
+func (sheet *Sheet) IsMerged(row, column int) bool {
+	_, ok := sheet.MergeAt(row, column)
+	return ok
+}
@@ -0,0 +1,48 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSheetMerges(t *testing.T) {
+	assert := assert.New(t)
+	sheet := &Sheet{}
+	err := json.Unmarshal([]byte(`{
+		"properties": {"sheetId": 7, "gridProperties": {"rowCount": 4, "columnCount": 4}},
+		"merges": [
+			{"sheetId": 7, "startRowIndex": 0, "endRowIndex": 1, "startColumnIndex": 0, "endColumnIndex": 3},
+			{"sheetId": 7, "startRowIndex": 2, "endRowIndex": 4, "startColumnIndex": 3, "endColumnIndex": 4}
+		],
+		"data": [{"rowData": [{"values": [{"formattedValue": "title"}]}]}]
+	}`), sheet)
+	assert.NoError(err)
+	assert.Equal(uint(7), sheet.Properties.ID)
+	assert.Equal("title", sheet.Rows[0][0].Value)
+
+	title := GridRange{SheetID: 7, EndRowIndex: 1, EndColumnIndex: 3}
+	assert.Equal([]GridRange{title, {SheetID: 7, StartRowIndex: 2, EndRowIndex: 4, StartColumnIndex: 3, EndColumnIndex: 4}}, sheet.Merges())
+
+	merge, ok := sheet.MergeAt(0, 2)
+	assert.True(ok)
+	assert.Equal(title, merge)
+	assert.True(sheet.IsMerged(3, 3))
+	assert.False(sheet.IsMerged(0, 3))
+	assert.False(sheet.IsMerged(1, 0))
+	assert.False(sheet.IsMerged(-1, 0))
+
+	sheet.Merges()[0].EndColumnIndex = 1
+	assert.True(sheet.IsMerged(0, 2))
+}
+
+func TestGridRangeContains(t *testing.T) {
+	assert := assert.New(t)
+	r := GridRange{StartRowIndex: 1, EndRowIndex: 3, StartColumnIndex: 2}
+	assert.True(r.Contains(1, 2))
+	assert.True(r.Contains(2, 100))
+	assert.False(r.Contains(3, 2))
+	assert.False(r.Contains(1, 1))
+	assert.True(GridRange{}.Contains(5, 5))
+}
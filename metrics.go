@@ -0,0 +1,50 @@
+package spreadsheet
+
+import "time"
+
+// MetricsCollector collects the metrics of the API calls of a service,
+// e.g. into Prometheus counters and histograms labelled by endpoint and status.
+type MetricsCollector interface {
+	// ObserveRequest is called after every request sent, retries included.
+	ObserveRequest(m RequestMetrics)
+	// ObserveRetry is called before a request is sent again after a response with the status.
+	ObserveRetry(endpoint string, status int)
+}
+
+// RequestMetrics are the metrics of a request.
+type RequestMetrics struct {
+	// Endpoint is the method and the path of the request, e.g. "GET /spreadsheets/{spreadsheetId}".
+	Endpoint string
+	// Status is the HTTP status of the response, or 0 when the request failed without a response.
+	Status        int
+	Latency       time.Duration
+	RequestBytes  int
+	ResponseBytes int
+}
+
+// WithMetrics sends the metrics of the API calls to the collector.
+func WithMetrics(collector MetricsCollector) ServiceOption {
+	return func(s *serviceSettings) {
+		s.metrics = collector
+	}
+}
+
+func (s *Service) observeRequest(method, url string, status int, d time.Duration, requestBytes, responseBytes int) {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.ObserveRequest(RequestMetrics{
+		Endpoint:      endpoint(method, url),
+		Status:        status,
+		Latency:       d,
+		RequestBytes:  requestBytes,
+		ResponseBytes: responseBytes,
+	})
+}
+
+func (s *Service) observeRetry(method, url string, status int) {
+	if s.metrics == nil {
+		return
+	}
+	s.metrics.ObserveRetry(endpoint(method, url), status)
+}
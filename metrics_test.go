@@ -0,0 +1,51 @@
+package spreadsheet
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMetrics struct {
+	requests []RequestMetrics
+	retries  []string
+}
+
+func (m *testMetrics) ObserveRequest(r RequestMetrics) {
+	m.requests = append(m.requests, r)
+}
+
+func (m *testMetrics) ObserveRetry(endpoint string, status int) {
+	m.retries = append(m.retries, endpoint)
+}
+
+func TestMetrics(t *testing.T) {
+	assert := assert.New(t)
+	statuses := []int{http.StatusTooManyRequests, http.StatusOK}
+	client := &http.Client{Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		status := statuses[0]
+		statuses = statuses[1:]
+		if status != http.StatusOK {
+			return jsonResponse(status, `{}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"clearedRange":"A1"}`), nil
+	})}
+	metrics := &testMetrics{}
+	s := NewServiceWithClient(client, WithMetrics(metrics))
+	s.SetRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+
+	_, err := s.ClearValues("abc", "A1")
+	assert.NoError(err)
+	endpoint := "POST /spreadsheets/{spreadsheetId}/values/{range}:clear"
+	if assert.Len(metrics.requests, 2) {
+		assert.Equal(endpoint, metrics.requests[0].Endpoint)
+		assert.Equal(http.StatusTooManyRequests, metrics.requests[0].Status)
+		assert.Equal(2, metrics.requests[0].RequestBytes)
+		assert.Equal(2, metrics.requests[0].ResponseBytes)
+		assert.Equal(http.StatusOK, metrics.requests[1].Status)
+		assert.Equal(len(`{"clearedRange":"A1"}`), metrics.requests[1].ResponseBytes)
+	}
+	assert.Equal([]string{endpoint}, metrics.retries)
+}
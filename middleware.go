@@ -0,0 +1,72 @@
+package spreadsheet
+
+import "net/http"
+
+// Middleware wraps the transport sending the requests of a service, e.g. to add headers,
+// log or measure the calls, or answer some of them from a cache.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an http.RoundTripper calling the function.
+type RoundTripperFunc func(req *http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware installs the middleware on the transport of the client of the service.
+// The first middleware given is the first to see the requests. The client itself is left
+// untouched, the service uses a copy of it.
+func WithMiddleware(middleware ...Middleware) ServiceOption {
+	return func(s *serviceSettings) {
+		s.middleware = append(s.middleware, middleware...)
+	}
+}
+
+// WithRequestHook calls the hook with every request before it is sent, e.g. to set a header.
+// The hook gets a copy of the request and its headers which it may change.
+func WithRequestHook(hook func(req *http.Request)) ServiceOption {
+	return WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			r := req.WithContext(req.Context())
+			r.Header = make(http.Header, len(req.Header))
+			for key, values := range req.Header {
+				r.Header[key] = append([]string(nil), values...)
+			}
+			hook(r)
+			return next.RoundTrip(r)
+		})
+	})
+}
+
+// WithResponseHook calls the hook with the response, or the error, of every request.
+// The hook must not read the body of the response.
+func WithResponseHook(hook func(req *http.Request, resp *http.Response, err error)) ServiceOption {
+	return WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			hook(req, resp, err)
+			return resp, err
+		})
+	})
+}
+
+// installMiddleware replaces the client of the service by a copy sending the requests through the middleware.
+func (s *Service) installMiddleware() {
+	if len(s.middleware) == 0 {
+		return
+	}
+	client := http.Client{}
+	if s.client != nil {
+		client = *s.client
+	}
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(s.middleware) - 1; i >= 0; i-- {
+		transport = s.middleware[i](transport)
+	}
+	client.Transport = transport
+	s.client = &client
+}
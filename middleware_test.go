@@ -0,0 +1,46 @@
+package spreadsheet
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	assert := assert.New(t)
+	var calls []string
+	var header http.Header
+	client := &http.Client{Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls = append(calls, "transport")
+		header = req.Header
+		return jsonResponse(http.StatusOK, `{"values":[]}`), nil
+	})}
+	tracing := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	var status int
+	s := NewServiceWithClient(client,
+		WithMiddleware(tracing("first"), tracing("second")),
+		WithRequestHook(func(req *http.Request) {
+			req.Header.Set("X-Goog-User-Project", "project")
+		}),
+		WithResponseHook(func(req *http.Request, resp *http.Response, err error) {
+			status = resp.StatusCode
+		}),
+	)
+
+	_, err := s.GetValues("abc", "A1")
+	assert.NoError(err)
+	assert.Equal([]string{"first", "second", "transport"}, calls)
+	assert.Equal("project", header.Get("X-Goog-User-Project"))
+	assert.Equal(http.StatusOK, status)
+	assert.False(client == s.client)
+	_, ok := client.Transport.(RoundTripperFunc)
+	assert.True(ok)
+}
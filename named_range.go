@@ -0,0 +1,99 @@
+package spreadsheet
+
+import "fmt"
+
+// NamedRange is a named range of a spreadsheet.
+type NamedRange struct {
+	NamedRangeID string    `json:"namedRangeId,omitempty"`
+	Name         string    `json:"name"`
+	Range        GridRange `json:"range"`
+}
+
+// NamedRange resolves the named range with the name to its sheet and its range.
+// Unbounded sides of the range are bounded by the grid of the sheet, so the range is empty
+// when it starts past the grid.
+func (spreadsheet *Spreadsheet) NamedRange(name string) (sheet *Sheet, gridRange GridRange, err error) {
+	for _, namedRange := range spreadsheet.NamedRanges {
+		if namedRange.Name != name {
+			continue
+		}
+		sheet, err = spreadsheet.SheetByID(namedRange.Range.SheetID)
+		if err != nil {
+			return
+		}
+		gridRange = sheet.boundRange(namedRange.Range)
+		return
+	}
+	err = fmt.Errorf("named range not found: %q", name)
+	return
+}
+
+// NamedRangeA1 resolves the named range with the name to a range in A1 notation qualified by the title of its sheet,
+// e.g. "'Config'!B2:B5". It fails when the range is empty, e.g. starting past the grid of its sheet.
+func (spreadsheet *Spreadsheet) NamedRangeA1(name string) (a1Range string, err error) {
+	sheet, gridRange, err := spreadsheet.NamedRange(name)
+	if err != nil {
+		return
+	}
+	if gridRange.EndRowIndex <= gridRange.StartRowIndex || gridRange.EndColumnIndex <= gridRange.StartColumnIndex {
+		err = fmt.Errorf("named range is empty: %q", name)
+		return
+	}
+	a1Range = sheet.a1Range(cellRange(gridRange.StartRowIndex, gridRange.StartColumnIndex,
+		gridRange.EndRowIndex-gridRange.StartRowIndex, gridRange.EndColumnIndex-gridRange.StartColumnIndex))
+	return
+}
+
+// NamedValues returns the values of the cells of the named range with the name, by row.
+func (spreadsheet *Spreadsheet) NamedValues(name string) (values [][]string, err error) {
+	sheet, gridRange, err := spreadsheet.NamedRange(name)
+	if err != nil {
+		return
+	}
+	sheet.mu.Lock()
+	defer sheet.mu.Unlock()
+	for row := gridRange.StartRowIndex; row < gridRange.EndRowIndex; row++ {
+		rowValues := make([]string, 0, gridRange.EndColumnIndex-gridRange.StartColumnIndex)
+		for column := gridRange.StartColumnIndex; column < gridRange.EndColumnIndex; column++ {
+			var cell Cell
+			cell, err = sheet.cell(int(row), int(column))
+			if err != nil {
+				return
+			}
+			rowValues = append(rowValues, cell.Value)
+		}
+		values = append(values, rowValues)
+	}
+	return
+}
+
+// NamedValue returns the value of the top left cell of the named range with the name, e.g. a single cell setting.
+func (spreadsheet *Spreadsheet) NamedValue(name string) (value string, err error) {
+	sheet, gridRange, err := spreadsheet.NamedRange(name)
+	if err != nil {
+		return
+	}
+	value, err = sheet.Value(int(gridRange.StartRowIndex), int(gridRange.StartColumnIndex))
+	return
+}
+
+// boundRange bounds the unbounded sides of the range by the grid of the sheet.
+// An unbounded side starting past the grid ends where it starts.
+func (sheet *Sheet) boundRange(gridRange GridRange) GridRange {
+	sheet.mu.Lock()
+	rows, columns := sheet.gridSize()
+	sheet.mu.Unlock()
+	if gridRange.EndRowIndex == 0 {
+		gridRange.EndRowIndex = rows
+		if gridRange.EndRowIndex < gridRange.StartRowIndex {
+			gridRange.EndRowIndex = gridRange.StartRowIndex
+		}
+	}
+	if gridRange.EndColumnIndex == 0 {
+		gridRange.EndColumnIndex = columns
+		if gridRange.EndColumnIndex < gridRange.StartColumnIndex {
+			gridRange.EndColumnIndex = gridRange.StartColumnIndex
+		}
+	}
+	return gridRange
+}
@@ -0,0 +1,63 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamedRange(t *testing.T) {
+	assert := assert.New(t)
+	var spreadsheet Spreadsheet
+	err := json.Unmarshal([]byte(`{
+		"spreadsheetId": "abc",
+		"namedRanges": [
+			{"namedRangeId": "1", "name": "rate", "range": {"sheetId": 7, "startRowIndex": 1, "endRowIndex": 2, "startColumnIndex": 1, "endColumnIndex": 2}},
+			{"namedRangeId": "2", "name": "names", "range": {"sheetId": 7, "startColumnIndex": 0, "endColumnIndex": 1}},
+			{"namedRangeId": "3", "name": "orphan", "range": {"sheetId": 9}},
+			{"namedRangeId": "4", "name": "past", "range": {"sheetId": 7, "startRowIndex": 5, "startColumnIndex": 0, "endColumnIndex": 1}}
+		],
+		"sheets": [{
+			"properties": {"sheetId": 7, "title": "Config", "gridProperties": {"rowCount": 3, "columnCount": 2}},
+			"data": [{"rowData": [
+				{"values": [{"formattedValue": "name"}, {"formattedValue": "rate"}]},
+				{"values": [{"formattedValue": "a"}, {"formattedValue": "0.5"}]}
+			]}]
+		}]
+	}`), &spreadsheet)
+	assert.NoError(err)
+
+	sheet, gridRange, err := spreadsheet.NamedRange("names")
+	assert.NoError(err)
+	assert.Equal("Config", sheet.Properties.Title)
+	assert.Equal(GridRange{SheetID: 7, EndRowIndex: 3, EndColumnIndex: 1}, gridRange)
+
+	a1Range, err := spreadsheet.NamedRangeA1("names")
+	assert.NoError(err)
+	assert.Equal("'Config'!A1:A3", a1Range)
+	a1Range, err = spreadsheet.NamedRangeA1("rate")
+	assert.NoError(err)
+	assert.Equal("'Config'!B2", a1Range)
+
+	values, err := spreadsheet.NamedValues("names")
+	assert.NoError(err)
+	assert.Equal([][]string{{"name"}, {"a"}, {""}}, values)
+	value, err := spreadsheet.NamedValue("rate")
+	assert.NoError(err)
+	assert.Equal("0.5", value)
+
+	_, err = spreadsheet.NamedValue("missing")
+	assert.EqualError(err, `named range not found: "missing"`)
+	_, _, err = spreadsheet.NamedRange("orphan")
+	assert.Error(err)
+
+	_, gridRange, err = spreadsheet.NamedRange("past")
+	assert.NoError(err)
+	assert.Equal(GridRange{SheetID: 7, StartRowIndex: 5, EndRowIndex: 5, EndColumnIndex: 1}, gridRange)
+	_, err = spreadsheet.NamedRangeA1("past")
+	assert.EqualError(err, `named range is empty: "past"`)
+	values, err = spreadsheet.NamedValues("past")
+	assert.NoError(err)
+	assert.Empty(values)
+}
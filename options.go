@@ -0,0 +1,314 @@
+package spreadsheet
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// ValueInputOption determines how input data should be interpreted.
+type ValueInputOption string
+
+// Value input options.
+const (
+	// ValueInputRaw stores the values as-is without parsing.
+	ValueInputRaw ValueInputOption = "RAW"
+	// ValueInputUserEntered parses the values as if the user typed them into the UI.
+	ValueInputUserEntered ValueInputOption = "USER_ENTERED"
+)
+
+// InsertDataOption determines how existing data is changed when new data is appended.
+type InsertDataOption string
+
+// Insert data options.
+const (
+	// InsertDataOverwrite overwrites the data after the table.
+	InsertDataOverwrite InsertDataOption = "OVERWRITE"
+	// InsertDataInsertRows inserts rows for the new data.
+	InsertDataInsertRows InsertDataOption = "INSERT_ROWS"
+)
+
+// ValueRenderOption determines how values are rendered when they are read.
+type ValueRenderOption string
+
+// Value render options.
+const (
+	// ValueRenderFormatted renders the values as they are displayed in the UI.
+	ValueRenderFormatted ValueRenderOption = "FORMATTED_VALUE"
+	// ValueRenderUnformatted renders the calculated values without formatting.
+	ValueRenderUnformatted ValueRenderOption = "UNFORMATTED_VALUE"
+	// ValueRenderFormula renders the formulas instead of their calculated values.
+	ValueRenderFormula ValueRenderOption = "FORMULA"
+)
+
+// DateTimeRenderOption determines how dates and times are rendered when values are not formatted.
+type DateTimeRenderOption string
+
+// Date time render options.
+const (
+	// DateTimeRenderSerialNumber renders dates and times as serial numbers, e.g. 43466.5.
+	DateTimeRenderSerialNumber DateTimeRenderOption = "SERIAL_NUMBER"
+	// DateTimeRenderFormattedString renders dates and times as strings in their number format.
+	DateTimeRenderFormattedString DateTimeRenderOption = "FORMATTED_STRING"
+)
+
+// Dimension indicates which dimension an operation applies to.
+type Dimension string
+
+// Dimensions.
+const (
+	DimensionRows    Dimension = "ROWS"
+	DimensionColumns Dimension = "COLUMNS"
+)
+
+// CallOption configures a single API call.
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	valueInputOption     ValueInputOption
+	insertDataOption     InsertDataOption
+	valueRenderOption    ValueRenderOption
+	dateTimeRenderOption DateTimeRenderOption
+	majorDimension       Dimension
+
+	includeValuesInResponse   bool
+	responseValueRenderOption ValueRenderOption
+
+	rewriteFormulaReferences bool
+	lazyLoading              bool
+	sparseCells              bool
+	skipReload               bool
+	effectiveValues          bool
+
+	syncBatchCells int
+	syncBatchBytes int
+
+	timeout  time.Duration
+	deadline time.Time
+}
+
+// SetDefaultCallOptions sets the options applied to every call of the service
+// before the options passed to the call.
+func (s *Service) SetDefaultCallOptions(opts ...CallOption) {
+	s.defaultOptions = opts
+}
+
+// SetDefaultCallOptions sets the options applied to every call on the spreadsheet and its sheets,
+// after the defaults of the service and before the options passed to the call.
+func (spreadsheet *Spreadsheet) SetDefaultCallOptions(opts ...CallOption) {
+	spreadsheet.defaultOptions = opts
+}
+
+// callOptions prepends the defaults of the spreadsheet of the sheet to the options.
+func (sheet *Sheet) callOptions(opts []CallOption) []CallOption {
+	if sheet.Spreadsheet == nil || len(sheet.Spreadsheet.defaultOptions) == 0 {
+		return opts
+	}
+	return append(append([]CallOption{}, sheet.Spreadsheet.defaultOptions...), opts...)
+}
+
+func (s *Service) newCallOptions(opts []CallOption) *callOptions {
+	return newCallOptions(s.defaultOptions, opts)
+}
+
+func newCallOptions(optsList ...[]CallOption) *callOptions {
+	o := &callOptions{
+		valueInputOption: ValueInputUserEntered,
+	}
+	for _, opts := range optsList {
+		for _, opt := range opts {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// callOptionsKey is the key of the options of a call in its context.
+type callOptionsKey struct{}
+
+// withCallOptions returns the context of a call with its options, limited by their timeout and deadline
+// for the whole call. The context is kept when it's already the context of a call and there are no options,
+// so that the options of the enclosing call apply. The calls within a call are never given more time.
+func (s *Service) withCallOptions(ctx context.Context, opts []CallOption) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Value(callOptionsKey{}).(*callOptions); ok && len(opts) == 0 {
+		return ctx, func() {}
+	}
+	o := s.newCallOptions(opts)
+	ctx, cancel := o.context(ctx)
+	return context.WithValue(ctx, callOptionsKey{}, o), cancel
+}
+
+// WithValueInputOption sets how the written values are interpreted.
+// The default is ValueInputUserEntered.
+func WithValueInputOption(option ValueInputOption) CallOption {
+	return func(o *callOptions) {
+		o.valueInputOption = option
+	}
+}
+
+// WithInsertDataOption sets how existing data is changed when appending values.
+// The default is InsertDataOverwrite.
+func WithInsertDataOption(option InsertDataOption) CallOption {
+	return func(o *callOptions) {
+		o.insertDataOption = option
+	}
+}
+
+// WithValueRenderOption sets how the read values are rendered.
+// The default is ValueRenderFormatted.
+func WithValueRenderOption(option ValueRenderOption) CallOption {
+	return func(o *callOptions) {
+		o.valueRenderOption = option
+	}
+}
+
+// WithDateTimeRenderOption sets how dates and times are rendered when the values are not formatted.
+// The default is DateTimeRenderSerialNumber.
+func WithDateTimeRenderOption(option DateTimeRenderOption) CallOption {
+	return func(o *callOptions) {
+		o.dateTimeRenderOption = option
+	}
+}
+
+// WithTimeout limits the time of the whole call, all its requests and their retries included,
+// independently of the timeout of the http.Client.
+func WithTimeout(d time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = d
+	}
+}
+
+// WithDeadline makes the whole call fail when it isn't done by the deadline, all its requests and their retries included.
+func WithDeadline(deadline time.Time) CallOption {
+	return func(o *callOptions) {
+		o.deadline = deadline
+	}
+}
+
+// context returns the context of the call limited by the earliest of the timeout and the deadline of the options.
+func (o *callOptions) context(ctx context.Context) (context.Context, context.CancelFunc) {
+	deadline := o.deadline
+	if o.timeout > 0 {
+		if d := time.Now().Add(o.timeout); deadline.IsZero() || d.Before(deadline) {
+			deadline = d
+		}
+	}
+	if deadline.IsZero() {
+		return ctx, func() {}
+	}
+	return context.WithDeadline(ctx, deadline)
+}
+
+// WithLazyLoading fetches the sheets of a spreadsheet without their cells,
+// which are loaded on demand by Sheet.LoadData.
+func WithLazyLoading() CallOption {
+	return func(o *callOptions) {
+		o.lazyLoading = true
+	}
+}
+
+// WithSparseCells keeps the cells of the fetched sheets sparse, for large sheets with mostly empty cells:
+// each of Rows and Columns is trimmed after its last non-empty cell instead of spanning the grid,
+// so their lengths vary. Cell and Value return the empty cells as usual.
+func WithSparseCells() CallOption {
+	return func(o *callOptions) {
+		o.sparseCells = true
+	}
+}
+
+// WithoutReload keeps the spreadsheet as it is after changing its properties or its sheets,
+// instead of reloading it from the response, to save the transfer of the sheets for write-heavy jobs.
+// The spreadsheet then needs a reload, see Spreadsheet.NeedsReload.
+func WithoutReload() CallOption {
+	return func(o *callOptions) {
+		o.skipReload = true
+	}
+}
+
+// withReload reloads the spreadsheet even if its default call options are WithoutReload,
+// for the calls which look up what they changed in the reloaded spreadsheet.
+func withReload() CallOption {
+	return func(o *callOptions) {
+		o.skipReload = false
+	}
+}
+
+// WithEffectiveValues also fetches the calculated values of the cells, see Cell.EffectiveValue.
+func WithEffectiveValues() CallOption {
+	return func(o *callOptions) {
+		o.effectiveValues = true
+	}
+}
+
+// WithFormulaReferenceRewrite rewrites references to a renamed sheet in the formulas of all sheets.
+func WithFormulaReferenceRewrite() CallOption {
+	return func(o *callOptions) {
+		o.rewriteFormulaReferences = true
+	}
+}
+
+// WithValuesInResponse includes the values stored by the server in the response
+// of a write, rendered with the option. An empty option renders formatted values.
+func WithValuesInResponse(option ValueRenderOption) CallOption {
+	return func(o *callOptions) {
+		o.includeValuesInResponse = true
+		o.responseValueRenderOption = option
+	}
+}
+
+// responseParams sets the query parameters requesting the written values in the response.
+func (o *callOptions) responseParams(params url.Values) {
+	if !o.includeValuesInResponse {
+		return
+	}
+	params.Set("includeValuesInResponse", "true")
+	if o.responseValueRenderOption != "" {
+		params.Set("responseValueRenderOption", string(o.responseValueRenderOption))
+	}
+	if o.dateTimeRenderOption != "" {
+		params.Set("responseDateTimeRenderOption", string(o.dateTimeRenderOption))
+	}
+}
+
+func (o *callOptions) majorDimensionOrRows() Dimension {
+	if o.majorDimension == "" {
+		return DimensionRows
+	}
+	return o.majorDimension
+}
+
+// WithMajorDimension sets whether the values are grouped by row or by column.
+// Values are grouped by row by default.
+func WithMajorDimension(dimension Dimension) CallOption {
+	return func(o *callOptions) {
+		o.majorDimension = dimension
+	}
+}
+
+// Default limits of the requests of a synchronization, see WithSyncBatchLimit.
+const (
+	DefaultSyncBatchCells = 50000
+	DefaultSyncBatchBytes = 2 << 20
+)
+
+// WithSyncBatchLimit splits the synchronization of a sheet into sequential requests of up to maxCells
+// modified cells and about maxBytes bytes of JSON each, so that huge synchronizations aren't rejected.
+// A limit of 0 keeps the default, DefaultSyncBatchCells or DefaultSyncBatchBytes.
+// When a request fails, the changes sent by the previous ones are kept to be synchronized again.
+func WithSyncBatchLimit(maxCells, maxBytes int) CallOption {
+	return func(o *callOptions) {
+		o.syncBatchCells = maxCells
+		o.syncBatchBytes = maxBytes
+	}
+}
+
+func (o *callOptions) syncBatchLimit() (maxCells, maxBytes int) {
+	maxCells, maxBytes = o.syncBatchCells, o.syncBatchBytes
+	if maxCells <= 0 {
+		maxCells = DefaultSyncBatchCells
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultSyncBatchBytes
+	}
+	return
+}
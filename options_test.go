@@ -0,0 +1,157 @@
+package spreadsheet
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCallOptions(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(ValueInputUserEntered, newCallOptions(nil).valueInputOption)
+	o := newCallOptions([]CallOption{WithValueInputOption(ValueInputRaw)})
+	assert.Equal(ValueInputRaw, o.valueInputOption)
+	assert.Equal(InsertDataOption(""), o.insertDataOption)
+	o = newCallOptions([]CallOption{WithInsertDataOption(InsertDataInsertRows)})
+	assert.Equal(InsertDataInsertRows, o.insertDataOption)
+}
+
+func TestDefaultCallOptions(t *testing.T) {
+	assert := assert.New(t)
+	s := &Service{}
+	s.SetDefaultCallOptions(WithValueInputOption(ValueInputRaw), WithValueRenderOption(ValueRenderFormula))
+	o := s.newCallOptions(nil)
+	assert.Equal(ValueInputRaw, o.valueInputOption)
+	assert.Equal(ValueRenderFormula, o.valueRenderOption)
+	o = s.newCallOptions([]CallOption{WithValueInputOption(ValueInputUserEntered)})
+	assert.Equal(ValueInputUserEntered, o.valueInputOption)
+	assert.Equal(ValueRenderFormula, o.valueRenderOption)
+}
+
+func TestResponseParams(t *testing.T) {
+	assert := assert.New(t)
+	params := url.Values{}
+	newCallOptions(nil).responseParams(params)
+	assert.Empty(params)
+	newCallOptions([]CallOption{WithValuesInResponse(ValueRenderUnformatted)}).responseParams(params)
+	assert.Equal("true", params.Get("includeValuesInResponse"))
+	assert.Equal("UNFORMATTED_VALUE", params.Get("responseValueRenderOption"))
+}
+
+func TestSpreadsheetDefaultCallOptions(t *testing.T) {
+	assert := assert.New(t)
+	s := &Service{}
+	s.SetDefaultCallOptions(WithValueInputOption(ValueInputRaw), WithValueRenderOption(ValueRenderFormula))
+	spreadsheet := &Spreadsheet{service: s}
+	sheet := &Sheet{Spreadsheet: spreadsheet}
+	assert.Empty(sheet.callOptions(nil))
+
+	spreadsheet.SetDefaultCallOptions(WithValueRenderOption(ValueRenderUnformatted), WithDateTimeRenderOption(DateTimeRenderFormattedString))
+	o := s.newCallOptions(sheet.callOptions(nil))
+	assert.Equal(ValueInputRaw, o.valueInputOption)
+	assert.Equal(ValueRenderUnformatted, o.valueRenderOption)
+	assert.Equal(DateTimeRenderFormattedString, o.dateTimeRenderOption)
+
+	o = s.newCallOptions(sheet.callOptions([]CallOption{WithValueRenderOption(ValueRenderFormatted)}))
+	assert.Equal(ValueRenderFormatted, o.valueRenderOption)
+	assert.Len(spreadsheet.defaultOptions, 2)
+}
+
+func TestCallOptionsContext(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	c, cancel := newCallOptions(nil).context(ctx)
+	defer cancel()
+	_, ok := c.Deadline()
+	assert.False(ok)
+
+	deadline := time.Now().Add(time.Hour)
+	c, cancel = newCallOptions([]CallOption{WithDeadline(deadline)}).context(ctx)
+	defer cancel()
+	d, ok := c.Deadline()
+	assert.True(ok)
+	assert.Equal(deadline, d)
+
+	c, cancel = newCallOptions([]CallOption{WithDeadline(deadline), WithTimeout(time.Minute)}).context(ctx)
+	defer cancel()
+	d, ok = c.Deadline()
+	assert.True(ok)
+	assert.True(d.Before(deadline.Add(-50 * time.Minute)))
+}
+
+func TestWithTimeout(t *testing.T) {
+	assert := assert.New(t)
+	s := NewServiceWithClient(&http.Client{Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})})
+	start := time.Now()
+	_, err := s.GetValues("abc", "A1", WithTimeout(10*time.Millisecond))
+	assert.Error(err)
+	assert.True(time.Since(start) < time.Second)
+}
+
+func TestTimeoutOfWholeCall(t *testing.T) {
+	assert := assert.New(t)
+	var calls int
+	s := NewServiceWithClient(&http.Client{Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		time.Sleep(20 * time.Millisecond)
+		return jsonResponse(http.StatusServiceUnavailable, `{"error":{"code":503,"message":"failed","status":"UNAVAILABLE"}}`), nil
+	})}, WithRetryPolicy(RetryPolicy{MaxAttempts: 100, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}))
+	start := time.Now()
+	_, err := s.GetValues("abc", "A1", WithTimeout(100*time.Millisecond))
+	assert.Equal(context.DeadlineExceeded, err)
+	assert.True(time.Since(start) < time.Second)
+	assert.True(calls < 10, "%d calls", calls)
+
+	// the requests within a call only have the time left to the call
+	s = NewServiceWithClient(&http.Client{Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		select {
+		case <-time.After(80 * time.Millisecond):
+			return jsonResponse(http.StatusOK, `{"values":[["a"]]}`), nil
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	})})
+	ctx, cancel := s.withCallOptions(context.Background(), []CallOption{WithTimeout(100 * time.Millisecond)})
+	defer cancel()
+	time.Sleep(60 * time.Millisecond)
+	_, err = s.GetValuesContext(ctx, "abc", "A1")
+	assert.Error(err)
+}
+
+func TestTimeoutOfInternalPaths(t *testing.T) {
+	assert := assert.New(t)
+	s := NewServiceWithClient(&http.Client{Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})})
+	spreadsheet := &Spreadsheet{ID: "abc", service: s, Sheets: []Sheet{{Properties: SheetProperties{ID: 7, Title: "s"}}}}
+	sheet := &spreadsheet.Sheets[0]
+	sheet.Spreadsheet = spreadsheet
+
+	calls := map[string]func() error{
+		"LoadData": func() error {
+			return sheet.LoadData(context.Background(), WithTimeout(10*time.Millisecond))
+		},
+		"UpdateRangeTransposed": func() error {
+			return sheet.UpdateRangeTransposed(0, 0, [][]interface{}{{"a"}}, WithDeadline(time.Now().Add(10*time.Millisecond)))
+		},
+		"default options of the service": func() error {
+			s.SetDefaultCallOptions(WithTimeout(10 * time.Millisecond))
+			defer s.SetDefaultCallOptions()
+			return s.SetCheckboxes(sheet, GridRange{})
+		},
+	}
+	for name, call := range calls {
+		start := time.Now()
+		assert.Error(call(), name)
+		assert.True(time.Since(start) < time.Second, name)
+	}
+}
@@ -5,6 +5,7 @@ type Properties struct {
 	Title      string `json:"title"`
 	Locale     string `json:"locale"`
 	AutoRecalc string `json:"autoRecalc"`
-	TimeZone   string `json:"timezone"`
+	TimeZone   string `json:"timeZone"`
 	// DefaultFormat *CellFormat `defaultFormat`
+	SpreadsheetTheme *SpreadsheetTheme `json:"spreadsheetTheme,omitempty"`
 }
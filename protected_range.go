@@ -0,0 +1,31 @@
+package spreadsheet
+
+// ProtectedRange is a range of a sheet protected from edits.
+type ProtectedRange struct {
+	ProtectedRangeID int       `json:"protectedRangeId,omitempty"`
+	Range            GridRange `json:"range"`
+	// NamedRangeID is set instead of Range when the protected range is a named range.
+	NamedRangeID string `json:"namedRangeId,omitempty"`
+	Description  string `json:"description,omitempty"`
+	WarningOnly  bool   `json:"warningOnly,omitempty"`
+	// RequestingUserCanEdit is whether the user who fetched the protected range can edit it. It is read-only.
+	RequestingUserCanEdit bool `json:"requestingUserCanEdit,omitempty"`
+	// UnprotectedRanges are the ranges within Range which are not protected.
+	UnprotectedRanges []GridRange `json:"unprotectedRanges,omitempty"`
+	// Editors is who can edit the protected range. It is only fetched for users who can edit it.
+	Editors *Editors `json:"editors,omitempty"`
+}
+
+// Editors is who can edit a protected range.
+type Editors struct {
+	// Users are the email addresses of the users.
+	Users []string `json:"users,omitempty"`
+	// Groups are the email addresses of the groups.
+	Groups             []string `json:"groups,omitempty"`
+	DomainUsersCanEdit bool     `json:"domainUsersCanEdit,omitempty"`
+}
+
+// ProtectedRanges returns the protected ranges of the sheet as of when it was fetched.
+func (sheet *Sheet) ProtectedRanges() []ProtectedRange {
+	return append([]ProtectedRange(nil), sheet.protectedRanges...)
+}
@@ -0,0 +1,40 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSheetProtectedRanges(t *testing.T) {
+	assert := assert.New(t)
+	sheet := &Sheet{}
+	err := json.Unmarshal([]byte(`{
+		"properties": {"sheetId": 7},
+		"protectedRanges": [
+			{
+				"protectedRangeId": 12,
+				"range": {"sheetId": 7, "endRowIndex": 1},
+				"description": "header",
+				"requestingUserCanEdit": true,
+				"unprotectedRanges": [{"sheetId": 7, "endRowIndex": 1, "startColumnIndex": 4, "endColumnIndex": 5}],
+				"editors": {"users": ["owner@example.com"], "groups": ["admins@example.com"]}
+			},
+			{"protectedRangeId": 13, "namedRangeId": "totals", "warningOnly": true}
+		]
+	}`), sheet)
+	assert.NoError(err)
+
+	assert.Equal([]ProtectedRange{
+		{
+			ProtectedRangeID:      12,
+			Range:                 GridRange{SheetID: 7, EndRowIndex: 1},
+			Description:           "header",
+			RequestingUserCanEdit: true,
+			UnprotectedRanges:     []GridRange{{SheetID: 7, EndRowIndex: 1, StartColumnIndex: 4, EndColumnIndex: 5}},
+			Editors:               &Editors{Users: []string{"owner@example.com"}, Groups: []string{"admins@example.com"}},
+		},
+		{ProtectedRangeID: 13, NamedRangeID: "totals", WarningOnly: true},
+	}, sheet.ProtectedRanges())
+}
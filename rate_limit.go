@@ -0,0 +1,74 @@
+package spreadsheet
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// SetRateLimit limits the calls of the service to requestsPerMinute, e.g. 60 to stay under
+// the write quota per user of the Sheets API. Up to burst calls are sent without waiting
+// after the service has been idle. A requestsPerMinute of 0 or less removes the limit.
+func (s *Service) SetRateLimit(requestsPerMinute, burst int) {
+	if requestsPerMinute <= 0 {
+		s.rateLimiter = nil
+		return
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	s.rateLimiter = &rateLimiter{
+		interval: time.Minute / time.Duration(requestsPerMinute),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+	}
+}
+
+// rateLimiter is a token bucket refilled with a token every interval, up to burst tokens.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+// wait blocks until a call may be sent or the context is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	d := l.reserve(time.Now())
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token at now and returns how long to wait until it is available.
+// The tokens go negative when calls are waiting, so that they are sent an interval apart.
+func (l *rateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.last.IsZero() && now.After(l.last) {
+		l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	if now.After(l.last) {
+		l.last = now
+	}
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens * float64(l.interval))
+}
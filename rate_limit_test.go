@@ -0,0 +1,54 @@
+package spreadsheet
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	assert := assert.New(t)
+	l := &rateLimiter{interval: time.Second, burst: 2, tokens: 2}
+	now := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(time.Duration(0), l.reserve(now))
+	assert.Equal(time.Duration(0), l.reserve(now))
+	assert.Equal(time.Second, l.reserve(now))
+	assert.Equal(2*time.Second, l.reserve(now))
+
+	now = now.Add(time.Second)
+	assert.Equal(2*time.Second, l.reserve(now))
+
+	now = now.Add(time.Hour)
+	assert.Equal(time.Duration(0), l.reserve(now))
+	assert.Equal(time.Duration(0), l.reserve(now))
+	assert.Equal(time.Second, l.reserve(now))
+}
+
+func TestServiceRateLimit(t *testing.T) {
+	assert := assert.New(t)
+	s := NewServiceWithClient(&http.Client{Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(http.StatusOK, `{"values":[]}`), nil
+	})})
+	s.SetRateLimit(6000, 1)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		_, err := s.GetValues("abc", "A1")
+		assert.NoError(err)
+	}
+	assert.True(time.Since(start) >= 20*time.Millisecond)
+
+	s.SetRateLimit(1, 1)
+	_, err := s.GetValues("abc", "A1")
+	assert.NoError(err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.GetValuesContext(ctx, "abc", "A1")
+	assert.Equal(context.Canceled, err)
+
+	s.SetRateLimit(0, 0)
+	assert.Nil(s.rateLimiter)
+}
@@ -0,0 +1,58 @@
+package spreadsheet
+
+// Reply is the reply to a request of a batch update. Only the field of the kind of the request is set,
+// and none for the kinds of request without a reply.
+type Reply struct {
+	AddNamedRange       *AddNamedRangeResponse       `json:"addNamedRange,omitempty"`
+	AddSheet            *AddSheetResponse            `json:"addSheet,omitempty"`
+	DuplicateSheet      *DuplicateSheetResponse      `json:"duplicateSheet,omitempty"`
+	AddFilterView       *AddFilterViewResponse       `json:"addFilterView,omitempty"`
+	DuplicateFilterView *DuplicateFilterViewResponse `json:"duplicateFilterView,omitempty"`
+	AddChart            *AddChartResponse            `json:"addChart,omitempty"`
+	AddProtectedRange   *AddProtectedRangeResponse   `json:"addProtectedRange,omitempty"`
+	FindReplace         *FindReplaceResponse         `json:"findReplace,omitempty"`
+}
+
+// AddNamedRangeResponse is the reply to adding a named range.
+type AddNamedRangeResponse struct {
+	NamedRange NamedRange `json:"namedRange"`
+}
+
+// AddSheetResponse is the reply to adding a sheet.
+type AddSheetResponse struct {
+	Properties SheetProperties `json:"properties"`
+}
+
+// DuplicateSheetResponse is the reply to duplicating a sheet.
+type DuplicateSheetResponse struct {
+	Properties SheetProperties `json:"properties"`
+}
+
+// AddFilterViewResponse is the reply to adding a filter view.
+type AddFilterViewResponse struct {
+	Filter FilterView `json:"filter"`
+}
+
+// DuplicateFilterViewResponse is the reply to duplicating a filter view.
+type DuplicateFilterViewResponse struct {
+	Filter FilterView `json:"filter"`
+}
+
+// AddChartResponse is the reply to adding a chart.
+type AddChartResponse struct {
+	Chart EmbeddedChart `json:"chart"`
+}
+
+// AddProtectedRangeResponse is the reply to adding a protected range.
+type AddProtectedRangeResponse struct {
+	ProtectedRange ProtectedRange `json:"protectedRange"`
+}
+
+// FindReplaceResponse is the reply to a find and replace, with the counts of what changed.
+type FindReplaceResponse struct {
+	ValuesChanged      int `json:"valuesChanged"`
+	FormulasChanged    int `json:"formulasChanged"`
+	RowsChanged        int `json:"rowsChanged"`
+	SheetsChanged      int `json:"sheetsChanged"`
+	OccurrencesChanged int `json:"occurrencesChanged"`
+}
@@ -0,0 +1,200 @@
+package spreadsheet
+
+import (
+	"context"
+	"errors"
+)
+
+var reportHeaderColor = &Color{Red: 0.85, Green: 0.85, Blue: 0.85, Alpha: 1}
+
+// ReportBuilder composes a report sheet: a title block, a table with a styled
+// header, a totals row and a chart over the table.
+// The whole report is created with a single batch update.
+type ReportBuilder struct {
+	sheetTitle  string
+	title       string
+	header      []string
+	rows        [][]interface{}
+	totalsLabel string
+	chartType   ChartType
+	chartTitle  string
+}
+
+// NewReportBuilder starts a report which is created as a new sheet with the title.
+func NewReportBuilder(sheetTitle string) *ReportBuilder {
+	return &ReportBuilder{sheetTitle: sheetTitle}
+}
+
+// Title adds a title block above the table.
+func (b *ReportBuilder) Title(title string) *ReportBuilder {
+	b.title = title
+	return b
+}
+
+// Table sets the header and the rows of the table.
+func (b *ReportBuilder) Table(header []string, rows [][]interface{}) *ReportBuilder {
+	b.header = header
+	b.rows = rows
+	return b
+}
+
+// Totals adds a row below the table which sums every numeric column.
+func (b *ReportBuilder) Totals(label string) *ReportBuilder {
+	b.totalsLabel = label
+	return b
+}
+
+// Chart adds a chart of the numeric columns over the first column of the table.
+func (b *ReportBuilder) Chart(chartType ChartType, title string) *ReportBuilder {
+	b.chartType = chartType
+	b.chartTitle = title
+	return b
+}
+
+// CreateReport creates the report as a new sheet of the spreadsheet and returns the sheet.
+func (s *Service) CreateReport(spreadsheet *Spreadsheet, report *ReportBuilder) (sheet *Sheet, err error) {
+	sheet, err = s.CreateReportContext(context.Background(), spreadsheet, report)
+	return
+}
+
+// CreateReportContext is like CreateReport with the context of the request.
+func (s *Service) CreateReportContext(ctx context.Context, spreadsheet *Spreadsheet, report *ReportBuilder) (sheet *Sheet, err error) {
+	r, err := newUpdateRequest(spreadsheet)
+	if err != nil {
+		return
+	}
+	sheetID := newSheetID(spreadsheet)
+	err = report.build(r, sheetID)
+	if err != nil {
+		return
+	}
+	// the sheet of the report is looked up in the reloaded spreadsheet
+	_, err = r.doAndReload(ctx, []CallOption{withReload()})
+	if err != nil {
+		return
+	}
+	sheet, err = spreadsheet.SheetByID(sheetID)
+	return
+}
+
+func (b *ReportBuilder) build(r *updateRequest, sheetID uint) error {
+	if len(b.header) == 0 {
+		return errors.New("report table must have a header")
+	}
+	width := uint(len(b.header))
+	var row uint
+	r.AddSheet(SheetProperties{ID: sheetID, Title: b.sheetTitle})
+
+	if b.title != "" {
+		r.UpdateCells(GridCoordinate{SheetID: sheetID}, []RowData{{Values: []CellData{{
+			UserEnteredValue:  extendedValueOf(b.title),
+			UserEnteredFormat: &CellFormat{TextFormat: &TextFormat{Bold: true, FontSize: 14}},
+		}}}}, "userEnteredValue,userEnteredFormat")
+		if width > 1 {
+			r.MergeCells(GridRange{SheetID: sheetID, EndRowIndex: 1, EndColumnIndex: width}, MergeAll)
+		}
+		row = 2
+	}
+
+	headerRow := row
+	header := RowData{Values: make([]CellData, len(b.header))}
+	for i, title := range b.header {
+		header.Values[i] = CellData{
+			UserEnteredValue: extendedValueOf(title),
+			UserEnteredFormat: &CellFormat{
+				BackgroundColor: reportHeaderColor,
+				TextFormat:      &TextFormat{Bold: true},
+			},
+		}
+	}
+	rows := []RowData{header}
+	for _, values := range b.rows {
+		rowData := RowData{Values: make([]CellData, len(values))}
+		for i, v := range values {
+			rowData.Values[i] = CellData{UserEnteredValue: extendedValueOf(v)}
+		}
+		rows = append(rows, rowData)
+	}
+	dataStart, dataEnd := headerRow+1, headerRow+1+uint(len(b.rows))
+	numeric := b.numericColumns()
+	if b.totalsLabel != "" {
+		totals := RowData{Values: make([]CellData, width)}
+		bold := &CellFormat{TextFormat: &TextFormat{Bold: true}}
+		totals.Values[0] = CellData{UserEnteredValue: extendedValueOf(b.totalsLabel), UserEnteredFormat: bold}
+		for _, column := range numeric {
+			formula := "=SUM(" + cellRange(dataStart, column, dataEnd-dataStart, 1) + ")"
+			totals.Values[column] = CellData{UserEnteredValue: extendedValueOf(formula), UserEnteredFormat: bold}
+		}
+		for i := range totals.Values {
+			if totals.Values[i].UserEnteredFormat == nil {
+				totals.Values[i].UserEnteredFormat = bold
+			}
+		}
+		rows = append(rows, totals)
+	}
+	r.UpdateCells(GridCoordinate{SheetID: sheetID, RowIndex: headerRow}, rows, "userEnteredValue,userEnteredFormat")
+
+	if b.chartType != "" {
+		if len(numeric) == 0 || len(b.rows) == 0 {
+			return errors.New("report chart needs rows with numeric columns")
+		}
+		spec := &BasicChartSpec{
+			ChartType:      b.chartType,
+			LegendPosition: LegendBottom,
+			HeaderCount:    1,
+			Axis: []BasicChartAxis{
+				{Position: AxisBottom, Title: b.header[0]},
+			},
+			Domains: []BasicChartDomain{{Domain: ChartData{SourceRange: ChartSourceRange{Sources: []GridRange{
+				{SheetID: sheetID, StartRowIndex: headerRow, EndRowIndex: dataEnd, StartColumnIndex: 0, EndColumnIndex: 1},
+			}}}}},
+		}
+		for _, column := range numeric {
+			spec.Series = append(spec.Series, BasicChartSeries{
+				Series: ChartData{SourceRange: ChartSourceRange{Sources: []GridRange{
+					{SheetID: sheetID, StartRowIndex: headerRow, EndRowIndex: dataEnd, StartColumnIndex: column, EndColumnIndex: column + 1},
+				}}},
+				TargetAxis: AxisLeft,
+			})
+		}
+		r.AddChart(EmbeddedChart{
+			Spec: ChartSpec{Title: b.chartTitle, BasicChart: spec},
+			Position: EmbeddedObjectPosition{OverlayPosition: &OverlayPosition{
+				AnchorCell: GridCoordinate{SheetID: sheetID, RowIndex: headerRow, ColumnIndex: width + 1},
+			}},
+		})
+	}
+	return nil
+}
+
+// numericColumns returns the columns, except the first one, whose values are all numbers.
+func (b *ReportBuilder) numericColumns() (columns []uint) {
+	for column := 1; column < len(b.header); column++ {
+		numeric := false
+		for _, values := range b.rows {
+			if column >= len(values) || values[column] == nil {
+				continue
+			}
+			if !isNumber(values[column]) {
+				numeric = false
+				break
+			}
+			numeric = true
+		}
+		if numeric {
+			columns = append(columns, uint(column))
+		}
+	}
+	return
+}
+
+// newSheetID returns an ID which is not used by the sheets of the spreadsheet.
+func newSheetID(spreadsheet *Spreadsheet) uint {
+	var id uint
+	for i := range spreadsheet.Sheets {
+		if spreadsheet.Sheets[i].Properties.ID > id {
+			id = spreadsheet.Sheets[i].Properties.ID
+		}
+	}
+	return id + 1
+}
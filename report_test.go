@@ -0,0 +1,56 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportBuilder(t *testing.T) {
+	assert := assert.New(t)
+	spreadsheet := &Spreadsheet{Sheets: []Sheet{{Properties: SheetProperties{ID: 7}}}}
+	r, err := newUpdateRequest(spreadsheet)
+	assert.NoError(err)
+
+	report := NewReportBuilder("Sales").
+		Title("Monthly sales").
+		Table([]string{"Region", "Q1", "Note", "Q2"}, [][]interface{}{
+			{"East", 10, "ok", 1.5},
+			{"West", 20, 3, nil},
+		}).
+		Totals("Total").
+		Chart("COLUMN", "Sales by region")
+	assert.Equal([]uint{1, 3}, report.numericColumns())
+	assert.NoError(report.build(r, newSheetID(spreadsheet)))
+
+	requests := r.body["requests"]
+	assert.Len(requests, 5)
+	b, err := json.Marshal(requests)
+	assert.NoError(err)
+	var got []map[string]map[string]interface{}
+	assert.NoError(json.Unmarshal(b, &got))
+
+	assert.Equal(float64(8), got[0]["addSheet"]["properties"].(map[string]interface{})["sheetId"])
+	assert.Equal(map[string]interface{}{"sheetId": float64(8), "endRowIndex": float64(1), "endColumnIndex": float64(4)},
+		got[2]["mergeCells"]["range"])
+
+	rows := got[3]["updateCells"]["rows"].([]interface{})
+	assert.Len(rows, 4)
+	totals := rows[3].(map[string]interface{})["values"].([]interface{})
+	value := func(cell interface{}) interface{} {
+		return cell.(map[string]interface{})["userEnteredValue"]
+	}
+	assert.Equal(map[string]interface{}{"formulaValue": "=SUM(B4:B5)"}, value(totals[1]))
+	assert.Nil(value(totals[2]))
+	assert.Equal(map[string]interface{}{"formulaValue": "=SUM(D4:D5)"}, value(totals[3]))
+
+	spec := got[4]["addChart"]["chart"].(map[string]interface{})["spec"].(map[string]interface{})
+	assert.Equal("Sales by region", spec["title"])
+	assert.Len(spec["basicChart"].(map[string]interface{})["series"], 2)
+}
+
+func TestReportBuilderWithoutHeader(t *testing.T) {
+	r, _ := newUpdateRequest(&Spreadsheet{})
+	assert.Error(t, NewReportBuilder("empty").build(r, 1))
+}
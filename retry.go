@@ -0,0 +1,88 @@
+package spreadsheet
+
+import (
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RetryPolicy configures how the calls failed by rate limiting (429) or server errors (500, 502, 503) are retried.
+// The calls which can't be sent twice, like appending values or a batch update of the spreadsheet,
+// are only retried on 429 and 503, as the API rejected them without processing them.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts of a call including the first one.
+	// Retries are disabled when it is 1 or less.
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry. It doubles before each following retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between two attempts.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy is the retry policy of new services.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialBackoff: time.Second,
+	MaxBackoff:     32 * time.Second,
+}
+
+// SetRetryPolicy sets the retry policy of the calls of the service.
+// Use RetryPolicy{} to disable retries.
+func (s *Service) SetRetryPolicy(policy RetryPolicy) {
+	s.retryPolicy = policy
+}
+
+// backoff returns the wait before the retry following the attempt, from 1.
+// The wait is jittered between the half and the whole of the exponential backoff
+// so that the clients rate limited together don't retry together.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+}
+
+func retryableStatus(status int, idempotent bool) bool {
+	switch status {
+	case http.StatusTooManyRequests,
+		http.StatusServiceUnavailable:
+		return true
+	case http.StatusInternalServerError,
+		http.StatusBadGateway:
+		return idempotent
+	}
+	return false
+}
+
+// nonIdempotentEndpoints are the calls sent by POST which change the spreadsheets again when sent twice,
+// e.g. appending the rows twice.
+var nonIdempotentEndpoints = []string{":append", ":copyTo", ":batchUpdate", "/spreadsheets"}
+
+// isIdempotent reports whether the request of the URL can be sent twice with the same result.
+func isIdempotent(method, rawURL string) bool {
+	if method != http.MethodPost {
+		return true
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	if strings.HasSuffix(u.Path, "/values:batchUpdate") {
+		return true
+	}
+	for _, suffix := range nonIdempotentEndpoints {
+		if strings.HasSuffix(u.Path, suffix) {
+			return false
+		}
+	}
+	return true
+}
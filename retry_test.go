@@ -0,0 +1,93 @@
+package spreadsheet
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	assert := assert.New(t)
+	p := RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	for attempt, max := range map[int]time.Duration{
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+		4: 5 * time.Second,
+		9: 5 * time.Second,
+	} {
+		for i := 0; i < 20; i++ {
+			d := p.backoff(attempt)
+			assert.True(d >= max/2 && d <= max, "attempt %d: %s", attempt, d)
+		}
+	}
+	assert.Equal(time.Duration(0), RetryPolicy{}.backoff(1))
+}
+
+func TestServiceRetry(t *testing.T) {
+	assert := assert.New(t)
+	var statuses []int
+	var calls int
+	onCall := func() {}
+	s := NewServiceWithClient(&http.Client{Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		status := statuses[calls]
+		calls++
+		onCall()
+		if status != http.StatusOK {
+			return jsonResponse(status, `{"error":{"code":503,"message":"failed","status":"UNAVAILABLE"}}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"values":[["a"]]}`), nil
+	})})
+	s.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	statuses, calls = []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK}, 0
+	valueRange, err := s.GetValues("abc", "A1")
+	assert.NoError(err)
+	assert.Equal([][]interface{}{{"a"}}, valueRange.Values)
+	assert.Equal(3, calls)
+	assert.Equal(2, s.Usage().Retries)
+
+	statuses, calls = []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway}, 0
+	_, err = s.UpdateValues("abc", "A1", [][]interface{}{{1}})
+	assert.Error(err)
+	assert.Equal(3, calls)
+
+	statuses, calls = []int{http.StatusBadGateway, http.StatusOK}, 0
+	_, err = s.AppendValues("abc", "A1", [][]interface{}{{1}})
+	assert.Error(err)
+	assert.Equal(1, calls)
+
+	statuses, calls = []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK}, 0
+	_, err = s.AppendValues("abc", "A1", [][]interface{}{{1}})
+	assert.NoError(err)
+	assert.Equal(3, calls)
+
+	statuses, calls = []int{http.StatusBadRequest}, 0
+	_, err = s.GetValues("abc", "A1")
+	assert.Error(err)
+	assert.Equal(1, calls)
+
+	s.SetRetryPolicy(RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	onCall = cancel
+	statuses, calls = []int{http.StatusServiceUnavailable}, 0
+	_, err = s.GetValuesContext(ctx, "abc", "A1")
+	assert.Equal(context.Canceled, err)
+	assert.Equal(1, calls)
+}
+
+func TestIsIdempotent(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(isIdempotent(http.MethodGet, "https://sheets.googleapis.com/v4/spreadsheets/abc"))
+	assert.True(isIdempotent(http.MethodPut, "https://sheets.googleapis.com/v4/spreadsheets/abc/values/A1"))
+	assert.True(isIdempotent(http.MethodPost, "https://sheets.googleapis.com/v4/spreadsheets/abc/values:batchUpdate"))
+	assert.True(isIdempotent(http.MethodPost, "https://sheets.googleapis.com/v4/spreadsheets/abc/values:batchClear"))
+	assert.True(isIdempotent(http.MethodPost, "https://sheets.googleapis.com/v4/spreadsheets/abc:getByDataFilter?fields=x"))
+	assert.False(isIdempotent(http.MethodPost, "https://sheets.googleapis.com/v4/spreadsheets/abc:batchUpdate"))
+	assert.False(isIdempotent(http.MethodPost, "https://sheets.googleapis.com/v4/spreadsheets/abc/values/A1:append?valueInputOption=RAW"))
+	assert.False(isIdempotent(http.MethodPost, "https://sheets.googleapis.com/v4/spreadsheets/abc/sheets/1:copyTo"))
+	assert.False(isIdempotent(http.MethodPost, "https://sheets.googleapis.com/v4/spreadsheets"))
+}
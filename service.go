@@ -2,24 +2,39 @@ package spreadsheet
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"os"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	"golang.org/x/oauth2/jwt"
 )
 
 const (
-	baseURL = "https://sheets.googleapis.com/v4"
+	baseURL      = "https://sheets.googleapis.com/v4"
+	driveBaseURL = "https://www.googleapis.com/drive/v3"
+
+	// userAgent ends with "(gzip)" as Google APIs need it to compress the responses.
+	userAgent = "Kayuii-spreadsheet (gzip)"
 
 	// Scope is the API scope for viewing and managing your Google Spreadsheet data.
 	// Useful for generating JWT values.
+	//
+	// Deprecated: Scope is the scope of the retired feeds API, use SpreadsheetsScope.
 	Scope = "https://spreadsheets.google.com/feeds"
 
 	// SecretFileName is used to get client.
@@ -42,104 +57,324 @@ const (
 	SpreadsheetsReadonlyScope = "https://www.googleapis.com/auth/spreadsheets.readonly"
 )
 
+// Scope presets for WithScopes, e.g. WithScopes(ReadOnlyScopes...).
+var (
+	// FullAccessScopes read and write the spreadsheets. They are the default.
+	FullAccessScopes = []string{SpreadsheetsScope}
+	// ReadOnlyScopes only read the spreadsheets, for organizations which don't grant full access.
+	ReadOnlyScopes = []string{SpreadsheetsReadonlyScope}
+	// ReadOnlyDriveScopes also list the spreadsheets with ListSpreadsheets.
+	ReadOnlyDriveScopes = []string{SpreadsheetsReadonlyScope, DriveReadonlyScope}
+)
+
 // NewServiceForCLI returns a gsheets client.
 // This function is intended for CLI tools.
-func NewServiceForCLI(ctx context.Context, authFile string) (s *Service, err error) {
+// The token of the user is kept in token.json in the working directory unless WithTokenStore is given.
+// A token saved before a change of WithScopes must be deleted to authorize the new scopes.
+func NewServiceForCLI(ctx context.Context, authFile string, opts ...ServiceOption) (s *Service, err error) {
 
 	cb, err := ioutil.ReadFile(authFile)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to read client secret file: %v", err)
 	}
 
-	config, err := google.ConfigFromJSON(cb, SpreadsheetsScope)
+	settings := newServiceSettings(opts)
+	config, err := google.ConfigFromJSON(cb, settings.scopes...)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to parse client secret file to config: %v", err)
 	}
 
-	tokenFile := "token.json"
-	tb, err := ioutil.ReadFile(tokenFile)
-
-	var token string
-	if err == nil {
-		token = string(tb)
-	} else {
-		// if there are no token file, get from Web
-
-		authURL := config.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
-		fmt.Printf("Go to the following link in your browser then type the "+
-			"authorization code: \n%v\n", authURL)
-
-		var authCode string
-		if _, err := fmt.Scan(&authCode); err != nil {
-			return nil, fmt.Errorf("Unable to read authorization code: %v", err)
+	store := settings.tokenStore
+	if store == nil {
+		store = FileTokenStore{Path: "token.json"}
+	}
+	tok, err := store.Load()
+	if err == ErrNoToken {
+		// if there are no token saved, get from Web
+		authFlow := settings.authFlow
+		if authFlow == nil {
+			authFlow = promptAuthCode
 		}
-
-		tok, err := config.Exchange(oauth2.NoContext, authCode)
+		tok, err = authFlow(ctx, config)
 		if err != nil {
-			return nil, fmt.Errorf("Unable to retrieve token from web: %v", err)
+			return
 		}
-
-		b := &bytes.Buffer{}
-		json.NewEncoder(b).Encode(tok)
-		token = b.String()
-
-		// save token
-		fmt.Printf("Saving credential file to: %s\n", tokenFile)
-		f, err := os.OpenFile(tokenFile, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
-		defer f.Close()
-		if err != nil {
+		if err = store.Save(tok); err != nil {
 			return nil, fmt.Errorf("Unable to cache oauth token: %v", err)
 		}
-		fmt.Fprint(f, token)
 	}
+	if err != nil {
+		return nil, fmt.Errorf("Unable to load oauth token: %v", err)
+	}
+	s = settings.build(config.Client(ctx, tok))
+	return
+}
+
+// promptAuthCode asks the user to authorize the CLI in a browser and to type the authorization code.
+func promptAuthCode(ctx context.Context, config *oauth2.Config) (tok *oauth2.Token, err error) {
+	authURL := config.AuthCodeURL("state-token", oauth2.AccessTypeOffline)
+	fmt.Printf("Go to the following link in your browser then type the "+
+		"authorization code: \n%v\n", authURL)
 
-	tok := &oauth2.Token{}
-	if err := json.NewDecoder(strings.NewReader(token)).Decode(tok); err != nil {
-		return nil, fmt.Errorf("Unable to parse json to token: %v", err)
+	var authCode string
+	if _, err := fmt.Scan(&authCode); err != nil {
+		return nil, fmt.Errorf("Unable to read authorization code: %v", err)
+	}
+
+	tok, err = config.Exchange(ctx, authCode)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to retrieve token from web: %v", err)
 	}
-	s = NewServiceWithClient(config.Client(ctx, tok))
 	return
 }
 
-// NewService makes a new service with the secret file.
-func NewService() (s *Service, err error) {
-	data, err := ioutil.ReadFile(SecretFileName)
+// NewService makes a new service configured by the options.
+// The client is made from the first of WithHTTPClient, WithTokenSource, WithCredentialsJSON and
+// WithCredentialsFile given, or from the service account of the secret file SecretFileName.
+func NewService(ctx context.Context, opts ...ServiceOption) (s *Service, err error) {
+	settings := newServiceSettings(opts)
+	client, err := settings.httpClient(ctx)
 	if err != nil {
 		return
 	}
+	s = settings.build(client)
+	return
+}
 
-	conf, err := google.JWTConfigFromJSON(data, Scope)
-	if err != nil {
+// NewServiceFromJSON makes a new service authorized as the service account of the JSON key,
+// e.g. loaded from a secret manager or an environment variable.
+func NewServiceFromJSON(ctx context.Context, data []byte, opts ...ServiceOption) (s *Service, err error) {
+	if len(data) == 0 {
+		err = errors.New("service account JSON must not be empty")
 		return
 	}
+	s, err = NewService(ctx, append([]ServiceOption{WithCredentialsJSON(data)}, opts...)...)
+	return
+}
+
+// NewServiceWithTokenSource makes a new service authorized by the tokens of the source,
+// e.g. from workload identity or a vault. The tokens are reused until they expire.
+func NewServiceWithTokenSource(ctx context.Context, tokenSource oauth2.TokenSource, opts ...ServiceOption) *Service {
+	return NewServiceWithClient(oauth2.NewClient(ctx, tokenSource), opts...)
+}
 
-	s = NewServiceWithClient(conf.Client(oauth2.NoContext))
+// NewServiceWithAPIKey makes a new service reading the spreadsheets shared with anyone with the link,
+// without OAuth. The API key only gives read access: the calls writing to a spreadsheet fail.
+func NewServiceWithAPIKey(apiKey string, opts ...ServiceOption) *Service {
+	return NewServiceWithClient(&http.Client{}, append([]ServiceOption{WithAPIKey(apiKey)}, opts...)...)
+}
+
+// NewServiceWithSubject makes a new service acting on behalf of the user of the subject, e.g. "user@corp.com",
+// with the service account of the secret file, or of WithCredentialsJSON or WithCredentialsFile.
+// The service account needs domain-wide delegation in the Workspace of the user.
+func NewServiceWithSubject(ctx context.Context, subject string, opts ...ServiceOption) (s *Service, err error) {
+	s, err = NewService(ctx, append([]ServiceOption{WithSubject(subject)}, opts...)...)
 	return
 }
 
 // NewServiceWithClient makes a new service by the client.
-func NewServiceWithClient(client *http.Client) *Service {
-	return &Service{
-		baseURL: baseURL,
-		client:  client,
+func NewServiceWithClient(client *http.Client, opts ...ServiceOption) *Service {
+	return newServiceSettings(opts).build(client)
+}
+
+// ServiceOption configures a new service.
+type ServiceOption func(*serviceSettings)
+
+// serviceSettings are the service being made and the settings of its client.
+type serviceSettings struct {
+	*Service
+	httpClientOption *http.Client
+	tokenSource      oauth2.TokenSource
+	credentialsJSON  []byte
+	credentialsFile  string
+	scopes           []string
+	subject          string
+	tokenStore       TokenStore
+	authFlow         func(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error)
+}
+
+func newServiceSettings(opts []ServiceOption) *serviceSettings {
+	settings := &serviceSettings{
+		Service: &Service{
+			baseURL:     baseURL,
+			retryPolicy: DefaultRetryPolicy,
+		},
+		scopes: FullAccessScopes,
+	}
+	for _, opt := range opts {
+		opt(settings)
+	}
+	return settings
+}
+
+// httpClient makes the client of the service from the settings.
+func (settings *serviceSettings) httpClient(ctx context.Context) (client *http.Client, err error) {
+	if settings.subject != "" && (settings.httpClientOption != nil || settings.tokenSource != nil) {
+		err = errors.New("the subject needs the credentials of a service account")
+		return
+	}
+	switch {
+	case settings.httpClientOption != nil:
+		client = settings.httpClientOption
+	case settings.tokenSource != nil:
+		client = oauth2.NewClient(ctx, settings.tokenSource)
+	default:
+		data := settings.credentialsJSON
+		if data == nil {
+			file := settings.credentialsFile
+			if file == "" {
+				file = SecretFileName
+			}
+			data, err = ioutil.ReadFile(file)
+			if err != nil {
+				return
+			}
+		}
+		var conf *jwt.Config
+		conf, err = google.JWTConfigFromJSON(data, settings.scopes...)
+		if err != nil {
+			return
+		}
+		if err = checkPrivateKey(conf.PrivateKey); err != nil {
+			return
+		}
+		conf.Subject = settings.subject
+		client = conf.Client(ctx)
+	}
+	return
+}
+
+// checkPrivateKey fails when the private key of a service account can't be parsed,
+// which the client would only report at the first call.
+func checkPrivateKey(key []byte) (err error) {
+	if block, _ := pem.Decode(key); block != nil {
+		key = block.Bytes
+	}
+	if _, err = x509.ParsePKCS8PrivateKey(key); err == nil {
+		return
+	}
+	if _, err = x509.ParsePKCS1PrivateKey(key); err != nil {
+		err = fmt.Errorf("malformed private key of the service account: %v", err)
+	}
+	return
+}
+
+// build makes the service with the client.
+func (settings *serviceSettings) build(client *http.Client) *Service {
+	s := settings.Service
+	s.client = client
+	s.installMiddleware()
+	return s
+}
+
+// WithHTTPClient makes the service send the requests with the client, which handles the authorization.
+func WithHTTPClient(client *http.Client) ServiceOption {
+	return func(s *serviceSettings) {
+		s.httpClientOption = client
+	}
+}
+
+// WithTokenSource authorizes the requests with the tokens of the source.
+func WithTokenSource(tokenSource oauth2.TokenSource) ServiceOption {
+	return func(s *serviceSettings) {
+		s.tokenSource = tokenSource
+	}
+}
+
+// WithCredentialsJSON authorizes the requests as the service account of the JSON key.
+func WithCredentialsJSON(data []byte) ServiceOption {
+	return func(s *serviceSettings) {
+		s.credentialsJSON = data
+	}
+}
+
+// WithCredentialsFile authorizes the requests as the service account of the JSON key file.
+func WithCredentialsFile(path string) ServiceOption {
+	return func(s *serviceSettings) {
+		s.credentialsFile = path
+	}
+}
+
+// WithAPIKey identifies the requests with the API key, passed in the X-Goog-Api-Key header
+// rather than in the URL, which errors and logs include.
+func WithAPIKey(apiKey string) ServiceOption {
+	return WithRequestHook(func(req *http.Request) {
+		req.Header.Set("X-Goog-Api-Key", apiKey)
+	})
+}
+
+// WithSubject makes the service account act on behalf of the user of the subject by domain-wide delegation.
+func WithSubject(subject string) ServiceOption {
+	return func(s *serviceSettings) {
+		s.subject = subject
+	}
+}
+
+// WithScopes sets the scopes requested for the service account or the user of the CLI.
+// The default is FullAccessScopes.
+func WithScopes(scopes ...string) ServiceOption {
+	return func(s *serviceSettings) {
+		s.scopes = scopes
+	}
+}
+
+// WithRetryPolicy sets the retry policy of the service, see SetRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) ServiceOption {
+	return func(s *serviceSettings) {
+		s.SetRetryPolicy(policy)
+	}
+}
+
+// WithRateLimit limits the calls of the service, see SetRateLimit.
+func WithRateLimit(requestsPerMinute, burst int) ServiceOption {
+	return func(s *serviceSettings) {
+		s.SetRateLimit(requestsPerMinute, burst)
+	}
+}
+
+// WithBaseURL sends the requests to the Sheets API at the URL instead of
+// https://sheets.googleapis.com/v4, e.g. to an emulator or through a proxy.
+func WithBaseURL(url string) ServiceOption {
+	return func(s *serviceSettings) {
+		s.baseURL = strings.TrimSuffix(url, "/")
 	}
 }
 
 // Service represents a Sheets API service instance.
 // Service is the main entry point into using this package.
 type Service struct {
-	baseURL string
-	client  *http.Client
+	baseURL        string
+	client         *http.Client
+	syncHooks      []SyncHooks
+	warningHandler func(Warning)
+	usage          usageRecorder
+	retryPolicy    RetryPolicy
+	rateLimiter    *rateLimiter
+	middleware     []Middleware
+	logger         Logger
+	tracer         Tracer
+	metrics        MetricsCollector
+	debug          bool
+	dryRun         dryRunRecorder
+
+	translateFormulas bool
+	defaultOptions    []CallOption
 }
 
 // CreateSpreadsheet creates a spreadsheet with the given title
 func (s *Service) CreateSpreadsheet(spreadsheet Spreadsheet) (resp Spreadsheet, err error) {
+	resp, err = s.CreateSpreadsheetContext(context.Background(), spreadsheet)
+	return
+}
+
+// CreateSpreadsheetContext is like CreateSpreadsheet with the context of the request.
+func (s *Service) CreateSpreadsheetContext(ctx context.Context, spreadsheet Spreadsheet) (resp Spreadsheet, err error) {
 	sheets := make([]map[string]interface{}, 1)
 	for s := range spreadsheet.Sheets {
-		sheet := spreadsheet.Sheets[s]
+		sheet := &spreadsheet.Sheets[s]
 		sheets = append(sheets, map[string]interface{}{"properties": map[string]interface{}{"title": sheet.Properties.Title}})
 	}
-	body, err := s.post("/spreadsheets", map[string]interface{}{
+	body, err := s.post(ctx, "/spreadsheets", map[string]interface{}{
 		"properties": map[string]interface{}{
 			"title": spreadsheet.Properties.Title,
 		},
@@ -152,228 +387,985 @@ func (s *Service) CreateSpreadsheet(spreadsheet Spreadsheet) (resp Spreadsheet,
 	if err != nil {
 		return
 	}
-	return s.FetchSpreadsheet(resp.ID)
+	return s.FetchSpreadsheetContext(ctx, resp.ID)
 }
 
 // FetchSpreadsheet fetches the spreadsheet by the id.
-func (s *Service) FetchSpreadsheet(id string) (spreadsheet Spreadsheet, err error) {
-	fields := "spreadsheetId,properties.title,sheets(properties,data.rowData.values(formattedValue,userEnteredValue))"
-	fields = url.QueryEscape(fields)
-	path := fmt.Sprintf("/spreadsheets/%s?fields=%s", id, fields)
-	body, err := s.get(path)
+// WithValueRenderOption chooses which values the cells of the sheets hold.
+// WithLazyLoading fetches the properties of the sheets only, see Sheet.LoadData.
+func (s *Service) FetchSpreadsheet(id string, opts ...CallOption) (spreadsheet Spreadsheet, err error) {
+	spreadsheet, err = s.FetchSpreadsheetContext(context.Background(), id, opts...)
+	return
+}
+
+// FetchSpreadsheetContext is like FetchSpreadsheet with the context of the request.
+func (s *Service) FetchSpreadsheetContext(ctx context.Context, id string, opts ...CallOption) (spreadsheet Spreadsheet, err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	if s.newCallOptions(opts).lazyLoading {
+		spreadsheet, err = s.FetchSpreadsheetMetadataContext(ctx, id)
+		spreadsheet.linkSheets()
+		return
+	}
+	spreadsheet, err = s.fetchSpreadsheet(ctx, id, nil, opts)
+	spreadsheet.linkSheets()
+	return
+}
+
+// FetchSpreadsheetRanges fetches the spreadsheet with the cells in the A1 ranges only,
+// e.g. "Sheet1" or "Sheet1!A1:C10". Cells outside of the ranges are left empty.
+func (s *Service) FetchSpreadsheetRanges(id string, ranges []string, opts ...CallOption) (spreadsheet Spreadsheet, err error) {
+	spreadsheet, err = s.FetchSpreadsheetRangesContext(context.Background(), id, ranges, opts...)
+	return
+}
+
+// FetchSpreadsheetRangesContext is like FetchSpreadsheetRanges with the context of the request.
+func (s *Service) FetchSpreadsheetRangesContext(ctx context.Context, id string, ranges []string, opts ...CallOption) (spreadsheet Spreadsheet, err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	if len(ranges) == 0 {
+		err = errors.New("ranges must not be empty")
+		return
+	}
+	spreadsheet, err = s.fetchSpreadsheet(ctx, id, ranges, opts)
+	spreadsheet.linkSheets()
+	return
+}
+
+// FetchOptions customizes what is fetched by FetchSpreadsheetWithOptions.
+type FetchOptions struct {
+	// Ranges limits the fetched cells to the A1 ranges.
+	Ranges []string
+	// Fields is the fields mask of the response, e.g. "sheets(properties,merges)".
+	// When it is empty, all fields are fetched, with the grid data only if IncludeGridData is set.
+	Fields string
+	// IncludeGridData fetches the grid data when Fields is empty.
+	IncludeGridData bool
+}
+
+// FetchSpreadsheetWithOptions fetches the spreadsheet with the fields chosen by the options.
+// The raw data of each sheet is kept in TmpData, so fields not modeled by Sheet can be decoded from it.
+func (s *Service) FetchSpreadsheetWithOptions(id string, fetchOptions FetchOptions, opts ...CallOption) (spreadsheet Spreadsheet, err error) {
+	spreadsheet, err = s.FetchSpreadsheetWithOptionsContext(context.Background(), id, fetchOptions, opts...)
+	return
+}
+
+// FetchSpreadsheetWithOptionsContext is like FetchSpreadsheetWithOptions with the context of the request.
+func (s *Service) FetchSpreadsheetWithOptionsContext(ctx context.Context, id string, fetchOptions FetchOptions, opts ...CallOption) (spreadsheet Spreadsheet, err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	params := url.Values{}
+	if fetchOptions.Fields != "" {
+		params.Set("fields", fetchOptions.Fields)
+	} else {
+		params.Set("includeGridData", strconv.FormatBool(fetchOptions.IncludeGridData))
+	}
+	spreadsheet, err = s.fetchSpreadsheetWithParams(ctx, id, fetchOptions.Ranges, params, opts)
+	spreadsheet.linkSheets()
+	return
+}
+
+// FetchSpreadsheetMetadata fetches the properties of the spreadsheet and its sheets
+// and the named ranges, without the cells, e.g. to list the sheets of a large spreadsheet.
+func (s *Service) FetchSpreadsheetMetadata(id string) (spreadsheet Spreadsheet, err error) {
+	spreadsheet, err = s.FetchSpreadsheetMetadataContext(context.Background(), id)
+	return
+}
+
+// FetchSpreadsheetMetadataContext is like FetchSpreadsheetMetadata with the context of the request.
+func (s *Service) FetchSpreadsheetMetadataContext(ctx context.Context, id string) (spreadsheet Spreadsheet, err error) {
+	params := url.Values{"fields": {metadataFields}}
+	spreadsheet, err = s.fetchSpreadsheetWithParams(ctx, id, nil, params, nil)
+	spreadsheet.linkSheets()
+	return
+}
+
+// FetchSpreadsheetByDataFilter fetches the spreadsheet with the cells in the ranges
+// matching any of the data filters only, e.g. ranges tagged with developer metadata.
+func (s *Service) FetchSpreadsheetByDataFilter(id string, filters []DataFilter, opts ...CallOption) (spreadsheet Spreadsheet, err error) {
+	spreadsheet, err = s.FetchSpreadsheetByDataFilterContext(context.Background(), id, filters, opts...)
+	return
+}
+
+// FetchSpreadsheetByDataFilterContext is like FetchSpreadsheetByDataFilter with the context of the request.
+func (s *Service) FetchSpreadsheetByDataFilterContext(ctx context.Context, id string, filters []DataFilter, opts ...CallOption) (spreadsheet Spreadsheet, err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	if len(filters) == 0 {
+		err = errors.New("filters must not be empty")
+		return
+	}
+	params := url.Values{"fields": {s.spreadsheetFields(opts)}}
+	path := fmt.Sprintf("/spreadsheets/%s:getByDataFilter?%s", id, params.Encode())
+	body, err := s.post(ctx, path, map[string]interface{}{
+		"dataFilters":     filters,
+		"includeGridData": true,
+	})
 	if err != nil {
 		return
 	}
-	err = json.Unmarshal(body, &spreadsheet)
+	spreadsheet, err = s.decodeSpreadsheet(strings.NewReader(body), opts)
+	spreadsheet.linkSheets()
+	return
+}
+
+// fetchSpreadsheet fetches the values of the cells needed by the sheets.
+func (s *Service) fetchSpreadsheet(ctx context.Context, id string, ranges []string, opts []CallOption) (spreadsheet Spreadsheet, err error) {
+	params := url.Values{"fields": {s.spreadsheetFields(opts)}}
+	spreadsheet, err = s.fetchSpreadsheetWithParams(ctx, id, ranges, params, opts)
+	return
+}
+
+// sheetFields is the fields mask of the properties of the sheets parsed besides their cells.
+const sheetFields = "properties,merges,conditionalFormats,protectedRanges,charts,bandedRanges,filterViews,basicFilter"
+
+// metadataFields is the fields mask of the spreadsheet without the cells.
+const metadataFields = "spreadsheetId,properties,sheets(" + sheetFields + "),namedRanges"
+
+// dimensionFields is the fields mask of the properties of the rows and the columns of the sheets.
+const dimensionFields = "pixelSize,hiddenByUser,hiddenByFilter"
+
+// spreadsheetFields returns the fields mask of the values of the cells needed by the sheets.
+func (s *Service) spreadsheetFields(opts []CallOption) string {
+	values := "formattedValue,userEnteredValue,hyperlink"
+	if o := s.newCallOptions(opts); o.valueRenderOption == ValueRenderUnformatted || o.effectiveValues {
+		values += ",effectiveValue"
+	}
+	return "spreadsheetId,properties,sheets(" + sheetFields + ",data(startRow,startColumn,rowData.values(" + values + ")," +
+		"rowMetadata(" + dimensionFields + "),columnMetadata(" + dimensionFields + "))),namedRanges"
+}
+
+func (s *Service) fetchSpreadsheetWithParams(ctx context.Context, id string, ranges []string, params url.Values, opts []CallOption) (spreadsheet Spreadsheet, err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	for _, r := range ranges {
+		params.Add("ranges", r)
+	}
+	path := fmt.Sprintf("/spreadsheets/%s?%s", id, params.Encode())
+	err = s.getStream(ctx, path, func(body io.Reader) (err error) {
+		spreadsheet, err = s.decodeSpreadsheet(body, opts)
+		return
+	})
+	return
+}
+
+// decodeSpreadsheet decodes the spreadsheet as it's read and renders the values of the cells by the options.
+func (s *Service) decodeSpreadsheet(body io.Reader, opts []CallOption) (spreadsheet Spreadsheet, err error) {
+	o := s.newCallOptions(opts)
+	spreadsheet, err = readSpreadsheet(body, o.sparseCells)
 	if err != nil {
 		return
 	}
 	spreadsheet.service = s
+	spreadsheet.linkSheets()
+	s.usage.cells(countGridCells(spreadsheet.Sheets), 0)
+	if s.translateFormulas {
+		translateFormulasFromLocale(&spreadsheet)
+	}
+	if o.valueRenderOption != "" && o.valueRenderOption != ValueRenderFormatted {
+		for i := range spreadsheet.Sheets {
+			spreadsheet.Sheets[i].renderValues(o.valueRenderOption)
+		}
+	}
 	return
 }
 
 // ReloadSpreadsheet reloads the spreadsheet
 func (s *Service) ReloadSpreadsheet(spreadsheet *Spreadsheet) (err error) {
-	newSpreadsheet, err := s.FetchSpreadsheet(spreadsheet.ID)
+	err = s.ReloadSpreadsheetContext(context.Background(), spreadsheet)
+	return
+}
+
+// ReloadSpreadsheetContext is like ReloadSpreadsheet with the context of the request.
+func (s *Service) ReloadSpreadsheetContext(ctx context.Context, spreadsheet *Spreadsheet) (err error) {
+	newSpreadsheet, err := s.FetchSpreadsheetContext(ctx, spreadsheet.ID, spreadsheet.defaultOptions...)
 	if err != nil {
 		return
 	}
-	spreadsheet.Properties = newSpreadsheet.Properties
-	spreadsheet.Sheets = newSpreadsheet.Sheets
+	spreadsheet.reload(newSpreadsheet)
 	return
 }
 
 // UpdateSpreadsheetTitle update spreadsheet title
-func (s *Service) UpdateSpreadsheetTitle(spreadsheet *Spreadsheet, properties Properties) (err error) {
+func (s *Service) UpdateSpreadsheetTitle(spreadsheet *Spreadsheet, properties Properties, opts ...CallOption) (err error) {
+	err = s.UpdateSpreadsheetTitleContext(context.Background(), spreadsheet, properties, opts...)
+	return
+}
+
+// UpdateSpreadsheetTitleContext is like UpdateSpreadsheetTitle with the context of the request.
+func (s *Service) UpdateSpreadsheetTitleContext(ctx context.Context, spreadsheet *Spreadsheet, properties Properties, opts ...CallOption) (err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
 	r, err := newUpdateRequest(spreadsheet)
 	if err != nil {
 		return
 	}
-	err = r.UpdateSpreadsheetProperties(&properties).Do()
+	_, err = r.UpdateSpreadsheetProperties(&properties).doAndReload(ctx, opts)
+	return
+}
+
+// UpdateSpreadsheetTheme updates the theme of the spreadsheet
+func (s *Service) UpdateSpreadsheetTheme(spreadsheet *Spreadsheet, theme SpreadsheetTheme, opts ...CallOption) (err error) {
+	err = s.UpdateSpreadsheetThemeContext(context.Background(), spreadsheet, theme, opts...)
+	return
+}
+
+// UpdateSpreadsheetThemeContext is like UpdateSpreadsheetTheme with the context of the request.
+func (s *Service) UpdateSpreadsheetThemeContext(ctx context.Context, spreadsheet *Spreadsheet, theme SpreadsheetTheme, opts ...CallOption) (err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	r, err := newUpdateRequest(spreadsheet)
 	if err != nil {
 		return
 	}
-	err = s.ReloadSpreadsheet(spreadsheet)
+	_, err = r.UpdateSpreadsheetProperties(&Properties{SpreadsheetTheme: &theme}).doAndReload(ctx, opts)
 	return
 }
 
-// UpdateSheetTitle update spreadsheet title
-func (s *Service) UpdateSheetTitle(sheet *Sheet, sheetProperties SheetProperties) (err error) {
+// UpdateSheetTitle update spreadsheet title.
+// WithFormulaReferenceRewrite also rewrites references to the old title in
+// the formulas of all sheets, such as ones in INDIRECT("'Old'!A1") which are
+// not updated automatically.
+func (s *Service) UpdateSheetTitle(sheet *Sheet, sheetProperties SheetProperties, opts ...CallOption) (err error) {
+	err = s.UpdateSheetTitleContext(context.Background(), sheet, sheetProperties, opts...)
+	return
+}
+
+// UpdateSheetTitleContext is like UpdateSheetTitle with the context of the request.
+func (s *Service) UpdateSheetTitleContext(ctx context.Context, sheet *Sheet, sheetProperties SheetProperties, opts ...CallOption) (err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	o := s.newCallOptions(sheet.callOptions(opts))
 	r, err := newUpdateRequest(sheet.Spreadsheet)
 	if err != nil {
 		return
 	}
-	err = r.UpdateSheetProperties(sheet, &sheetProperties).Do()
+	r.UpdateSheetProperties(sheet, &sheetProperties)
+	if o.rewriteFormulaReferences && sheetProperties.Title != sheet.Properties.Title {
+		r.FindReplace(formulaReferencePattern(sheet.Properties.Title), quoteSheetTitle(sheetProperties.Title)+"!", true, true)
+	}
+	_, err = r.doAndReload(ctx, opts)
+	return
+}
+
+// AddSheet adds a sheet
+func (s *Service) AddSheet(spreadsheet *Spreadsheet, sheetProperties SheetProperties, opts ...CallOption) (err error) {
+	err = s.AddSheetContext(context.Background(), spreadsheet, sheetProperties, opts...)
+	return
+}
+
+// AddSheetContext is like AddSheet with the context of the request.
+func (s *Service) AddSheetContext(ctx context.Context, spreadsheet *Spreadsheet, sheetProperties SheetProperties, opts ...CallOption) (err error) {
+	_, err = s.AddSheetReplyContext(ctx, spreadsheet, sheetProperties, opts...)
+	return
+}
+
+// AddSheetReply is like AddSheet and returns the reply of the API, with the properties of the new sheet,
+// e.g. the ID assigned to it. The reply is empty when there is none, e.g. in dry-run mode.
+func (s *Service) AddSheetReply(spreadsheet *Spreadsheet, sheetProperties SheetProperties, opts ...CallOption) (reply AddSheetResponse, err error) {
+	reply, err = s.AddSheetReplyContext(context.Background(), spreadsheet, sheetProperties, opts...)
+	return
+}
+
+// AddSheetReplyContext is like AddSheetReply with the context of the request.
+func (s *Service) AddSheetReplyContext(ctx context.Context, spreadsheet *Spreadsheet, sheetProperties SheetProperties, opts ...CallOption) (reply AddSheetResponse, err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	r, err := newUpdateRequest(spreadsheet)
 	if err != nil {
 		return
 	}
-	err = s.ReloadSpreadsheet(sheet.Spreadsheet)
+	replies, err := r.AddSheet(sheetProperties).doAndReload(ctx, opts)
+	if err == nil && len(replies) > 0 && replies[0].AddSheet != nil {
+		reply = *replies[0].AddSheet
+	}
 	return
 }
 
-// AddSheet adds a sheet
-func (s *Service) AddSheet(spreadsheet *Spreadsheet, sheetProperties SheetProperties) (err error) {
+// DeleteSheet deletes the sheet
+func (s *Service) DeleteSheet(spreadsheet *Spreadsheet, sheetID uint, opts ...CallOption) (err error) {
+	err = s.DeleteSheetContext(context.Background(), spreadsheet, sheetID, opts...)
+	return
+}
+
+// DeleteSheetContext is like DeleteSheet with the context of the request.
+func (s *Service) DeleteSheetContext(ctx context.Context, spreadsheet *Spreadsheet, sheetID uint, opts ...CallOption) (err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
 	r, err := newUpdateRequest(spreadsheet)
 	if err != nil {
 		return
 	}
-	err = r.AddSheet(sheetProperties).Do()
+	_, err = r.DeleteSheet(sheetID).doAndReload(ctx, opts)
+	return
+}
+
+// CopySheetTo copies the sheet of the source spreadsheet into the destination spreadsheet
+// and returns the properties of the new sheet.
+func (s *Service) CopySheetTo(sourceSpreadsheetID string, sheetID uint, destinationSpreadsheetID string) (properties SheetProperties, err error) {
+	properties, err = s.CopySheetToContext(context.Background(), sourceSpreadsheetID, sheetID, destinationSpreadsheetID)
+	return
+}
+
+// CopySheetToContext is like CopySheetTo with the context of the request.
+func (s *Service) CopySheetToContext(ctx context.Context, sourceSpreadsheetID string, sheetID uint, destinationSpreadsheetID string) (properties SheetProperties, err error) {
+	path := fmt.Sprintf("/spreadsheets/%s/sheets/%d:copyTo", sourceSpreadsheetID, sheetID)
+	body, err := s.post(ctx, path, map[string]interface{}{
+		"destinationSpreadsheetId": destinationSpreadsheetID,
+	})
 	if err != nil {
 		return
 	}
-	err = s.ReloadSpreadsheet(spreadsheet)
+	err = json.Unmarshal([]byte(body), &properties)
 	return
 }
 
-// DeleteSheet deletes the sheet
-func (s *Service) DeleteSheet(spreadsheet *Spreadsheet, sheetID uint) (err error) {
+// DeleteSheetsMatching deletes the sheets whose titles match the pattern in one batch update
+// and returns their titles. Nothing is sent when no sheet matches.
+func (s *Service) DeleteSheetsMatching(spreadsheet *Spreadsheet, pattern *regexp.Regexp, opts ...CallOption) (deleted []string, err error) {
+	deleted, err = s.DeleteSheetsMatchingContext(context.Background(), spreadsheet, pattern, opts...)
+	return
+}
+
+// DeleteSheetsMatchingContext is like DeleteSheetsMatching with the context of the request.
+func (s *Service) DeleteSheetsMatchingContext(ctx context.Context, spreadsheet *Spreadsheet, pattern *regexp.Regexp, opts ...CallOption) (deleted []string, err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
 	r, err := newUpdateRequest(spreadsheet)
 	if err != nil {
 		return
 	}
-	err = r.DeleteSheet(sheetID).Do()
+	for i := range spreadsheet.Sheets {
+		sheet := &spreadsheet.Sheets[i]
+		if pattern.MatchString(sheet.Properties.Title) {
+			r.DeleteSheet(sheet.Properties.ID)
+			deleted = append(deleted, sheet.Properties.Title)
+		}
+	}
+	if len(deleted) == 0 {
+		return
+	}
+	if len(deleted) == len(spreadsheet.Sheets) {
+		err = errors.New("cannot delete all sheets of the spreadsheet")
+		deleted = nil
+		return
+	}
+	_, err = r.doAndReload(ctx, opts)
+	if err != nil {
+		deleted = nil
+	}
+	return
+}
+
+// SyncSheet updates sheet.
+// WithValueInputOption sets how the values are interpreted, USER_ENTERED by default.
+func (s *Service) SyncSheet(sheet *Sheet, opts ...CallOption) (result SyncResult, err error) {
+	result, err = s.SyncSheetContext(context.Background(), sheet, opts...)
+	return
+}
+
+// SyncSheetContext is like SyncSheet with the context of the request.
+func (s *Service) SyncSheetContext(ctx context.Context, sheet *Sheet, opts ...CallOption) (result SyncResult, err error) {
+	start := time.Now()
+	err = s.beforeSync(sheet)
+	if err != nil {
+		s.onSyncError(sheet, err)
+		return
+	}
+	o := s.newCallOptions(sheet.callOptions(opts))
+	if err = o.validate(); err != nil {
+		s.onSyncError(sheet, err)
+		return
+	}
+	ctx, cancel := s.withCallOptions(ctx, sheet.callOptions(opts))
+	defer cancel()
+	t := &transfer{}
+	cells, rows, columns := sheet.takeChanges()
+	result.CellsUpdated = len(cells)
+	result.RangesSent, err = s.syncSheet(ctx, sheet, cells, rows, columns, t, o)
+	result.APICalls = t.calls
+	result.BytesTransferred = t.bytes
+	result.Duration = time.Since(start)
 	if err != nil {
+		sheet.restoreChanges(cells)
+		s.onSyncError(sheet, err)
 		return
 	}
-	err = s.ReloadSpreadsheet(spreadsheet)
+	s.afterSync(sheet, result)
 	return
 }
 
-// SyncSheet updates sheet
-func (s *Service) SyncSheet(sheet *Sheet) (err error) {
-	if sheet.newMaxRow > sheet.Properties.GridProperties.RowCount ||
-		sheet.newMaxColumn > sheet.Properties.GridProperties.ColumnCount {
-		err = s.ExpandSheet(sheet, sheet.newMaxRow, sheet.newMaxColumn)
+// syncSheet sends the changes taken from the sheet, expanding it to the rows and the columns they need.
+func (s *Service) syncSheet(ctx context.Context, sheet *Sheet, cells []*Cell, rows, columns uint, t *transfer, o *callOptions) (ranges int, err error) {
+	err = s.expandSheet(ctx, sheet, rows, columns, t)
+	if err != nil {
+		return
+	}
+	var formulas, values []*Cell
+	for _, cell := range cells {
+		if _, ok := cell.FormulaValue(); ok {
+			formulas = append(formulas, cell)
+		} else {
+			values = append(values, cell)
+		}
+	}
+	if len(formulas) > 0 {
+		ranges, err = s.syncFormulas(ctx, sheet, formulas, t, o)
+		if err != nil || len(values) == 0 {
+			return
+		}
+	}
+	n, err := s.syncCells(ctx, sheet, values, t, o)
+	ranges += n
+	return
+}
+
+// syncFormulas sends the cells with formulas as the formula values entered in the cells,
+// so that they're formulas whatever the value input option.
+func (s *Service) syncFormulas(ctx context.Context, sheet *Sheet, cells []*Cell, t *transfer, o *callOptions) (ranges int, err error) {
+	var translator *FormulaTranslator
+	if s.translateFormulas {
+		translator = sheet.Spreadsheet.FormulaTranslator()
+	}
+	maxCells, maxBytes := o.syncBatchLimit()
+	var r *updateRequest
+	var batchCells, batchBytes int
+	for _, block := range splitBlocks(cellBlocks(cellRuns(cells, DimensionRows), DimensionRows), maxCells) {
+		rows := make([]RowData, len(block))
+		for i, run := range block {
+			rows[i].Values = make([]CellData, len(run))
+			for j, cell := range run {
+				formula, _ := cell.FormulaValue()
+				if translator != nil {
+					formula = translator.ToLocale(formula)
+				}
+				rows[i].Values[j].UserEnteredValue = ExtendedValue{FormulaValue: formula}
+			}
+		}
+		var data []byte
+		data, err = json.Marshal(rows)
 		if err != nil {
 			return
 		}
+		n := len(block) * len(block[0])
+		if r != nil && (batchCells+n > maxCells || batchBytes+len(data) > maxBytes) {
+			if _, err = r.do(ctx, t); err != nil {
+				return
+			}
+			r = nil
+		}
+		if r == nil {
+			if r, err = newUpdateRequest(sheet.Spreadsheet); err != nil {
+				return
+			}
+			batchCells, batchBytes = 0, 0
+		}
+		first := block[0][0]
+		r.UpdateCells(GridCoordinate{SheetID: sheet.Properties.ID, RowIndex: first.Row, ColumnIndex: first.Column}, rows, "userEnteredValue")
+		batchCells += n
+		batchBytes += len(data)
+		ranges++
 	}
-	err = s.syncCells(sheet)
-	if err != nil {
+	if _, err = r.do(ctx, t); err != nil {
 		return
 	}
-	sheet.modifiedCells = []*Cell{}
-	sheet.newMaxRow = sheet.Properties.GridProperties.RowCount
-	sheet.newMaxColumn = sheet.Properties.GridProperties.ColumnCount
+	s.usage.cells(0, len(cells))
 	return
 }
 
-// ExpandSheet expands the range of the sheet
+// ExpandSheet expands the range of the sheet to at least the rows and the columns. It never shrinks the sheet.
 func (s *Service) ExpandSheet(sheet *Sheet, row, column uint) (err error) {
-	props := sheet.Properties
-	props.GridProperties.RowCount = row
-	props.GridProperties.ColumnCount = column
+	err = s.ExpandSheetContext(context.Background(), sheet, row, column)
+	return
+}
+
+// ExpandSheetContext is like ExpandSheet with the context of the request.
+func (s *Service) ExpandSheetContext(ctx context.Context, sheet *Sheet, row, column uint) (err error) {
+	err = s.expandSheet(ctx, sheet, row, column, nil)
+	return
+}
+
+// expandSheet grows the grid of the sheet to at least the rows and the columns, if needed.
+// Expansions are serialized, so that an expansion can't be overtaken by a smaller one and shrink the grid.
+func (s *Service) expandSheet(ctx context.Context, sheet *Sheet, row, column uint, t *transfer) (err error) {
+	sheet.expandMu.Lock()
+	defer sheet.expandMu.Unlock()
+	sheet.mu.Lock()
+	grid := sheet.Properties.GridProperties
+	sheet.mu.Unlock()
+	if row <= grid.RowCount && column <= grid.ColumnCount {
+		return
+	}
+	if row < grid.RowCount {
+		row = grid.RowCount
+	}
+	if column < grid.ColumnCount {
+		column = grid.ColumnCount
+	}
 
 	r, err := newUpdateRequest(sheet.Spreadsheet)
 	if err != nil {
 		return
 	}
-	err = r.UpdateSheetProperties(sheet, &props).Do()
+	_, err = r.UpdateGridSize(sheet.Properties.ID, row, column).do(ctx, t)
 	if err != nil {
 		return
 	}
-	sheet.newMaxRow = row
-	sheet.newMaxColumn = column
+	sheet.mu.Lock()
+	defer sheet.mu.Unlock()
+	sheet.Properties.GridProperties.RowCount = row
+	sheet.Properties.GridProperties.ColumnCount = column
+	// cells updated meanwhile may need more
+	if sheet.newMaxRow < row {
+		sheet.newMaxRow = row
+	}
+	if sheet.newMaxColumn < column {
+		sheet.newMaxColumn = column
+	}
 	return
 }
 
 // AppendCells inserts rows into the sheet
 func (s *Service) AppendCells(sheet *Sheet, rows [][]Cell) (err error) {
+	err = s.AppendCellsContext(context.Background(), sheet, rows)
+	return
+}
+
+// AppendCellsContext is like AppendCells with the context of the request.
+func (s *Service) AppendCellsContext(ctx context.Context, sheet *Sheet, rows [][]Cell) (err error) {
 	r, err := newUpdateRequest(sheet.Spreadsheet)
 	if err != nil {
 		return
 	}
-	err = r.AppendCells(sheet, rows).Do()
+	_, err = r.AppendCells(sheet, rows).Do(ctx)
 	return
 }
 
 // InsertRows inserts rows into the sheet
 func (s *Service) InsertRows(sheet *Sheet, start, end int) (err error) {
-	sheet.Properties.GridProperties.RowCount -= uint(end - start)
-	sheet.newMaxRow -= uint(end - start)
+	err = s.InsertRowsContext(context.Background(), sheet, start, end)
+	return
+}
+
+// InsertRowsContext is like InsertRows with the context of the request.
+func (s *Service) InsertRowsContext(ctx context.Context, sheet *Sheet, start, end int) (err error) {
+	err = s.insertDimension(ctx, sheet, DimensionRows, start, end)
+	return
+}
+
+// InsertColumns inserts columns into the sheet
+func (s *Service) InsertColumns(sheet *Sheet, start, end int) (err error) {
+	err = s.InsertColumnsContext(context.Background(), sheet, start, end)
+	return
+}
+
+// InsertColumnsContext is like InsertColumns with the context of the request.
+func (s *Service) InsertColumnsContext(ctx context.Context, sheet *Sheet, start, end int) (err error) {
+	err = s.insertDimension(ctx, sheet, DimensionColumns, start, end)
+	return
+}
+
+// AppendColumns appends empty columns to the end of the sheet
+func (s *Service) AppendColumns(sheet *Sheet, length int) (err error) {
+	err = s.AppendColumnsContext(context.Background(), sheet, length)
+	return
+}
+
+// AppendColumnsContext is like AppendColumns with the context of the request.
+func (s *Service) AppendColumnsContext(ctx context.Context, sheet *Sheet, length int) (err error) {
+	if length <= 0 {
+		err = errors.New("length must be positive")
+		return
+	}
 	r, err := newUpdateRequest(sheet.Spreadsheet)
 	if err != nil {
 		return
 	}
-	err = r.InsertDimension(sheet, "ROWS", start, end).Do()
+	_, err = r.AppendDimension(sheet, DimensionColumns, length).Do(ctx)
+	if err != nil {
+		return
+	}
+	end := int(sheet.Properties.GridProperties.ColumnCount) + length
+	sheet.resizeDimension(DimensionColumns, end-length, end, true)
 	return
 }
 
-// DeleteRows deletes rows from the sheet
-func (s *Service) DeleteRows(sheet *Sheet, start, end int) (err error) {
-	sheet.Properties.GridProperties.RowCount -= uint(end - start)
-	sheet.newMaxRow -= uint(end - start)
+// HideColumns hides columns of the sheet
+func (s *Service) HideColumns(sheet *Sheet, start, end int) (err error) {
+	err = s.HideColumnsContext(context.Background(), sheet, start, end)
+	return
+}
+
+// HideColumnsContext is like HideColumns with the context of the request.
+func (s *Service) HideColumnsContext(ctx context.Context, sheet *Sheet, start, end int) (err error) {
+	err = validateDimensionRange(start, end)
+	if err != nil {
+		return
+	}
 	r, err := newUpdateRequest(sheet.Spreadsheet)
 	if err != nil {
 		return
 	}
-	err = r.DeleteDimension(sheet, "ROWS", start, end).Do()
+	_, err = r.UpdateDimensionProperties(sheet, DimensionColumns, start, end, &DimensionProperties{HiddenByUser: true}, "hiddenByUser").Do(ctx)
+	return
+}
+
+// DeleteRows deletes rows from the sheet
+func (s *Service) DeleteRows(sheet *Sheet, start, end int) (err error) {
+	err = s.DeleteRowsContext(context.Background(), sheet, start, end)
+	return
+}
+
+// DeleteRowsContext is like DeleteRows with the context of the request.
+func (s *Service) DeleteRowsContext(ctx context.Context, sheet *Sheet, start, end int) (err error) {
+	err = s.deleteDimension(ctx, sheet, DimensionRows, start, end)
 	return
 }
 
 // DeleteColumns deletes columns from the sheet
 func (s *Service) DeleteColumns(sheet *Sheet, start, end int) (err error) {
-	sheet.Properties.GridProperties.ColumnCount -= uint(end - start)
-	sheet.newMaxRow -= uint(end - start)
+	err = s.DeleteColumnsContext(context.Background(), sheet, start, end)
+	return
+}
+
+// DeleteColumnsContext is like DeleteColumns with the context of the request.
+func (s *Service) DeleteColumnsContext(ctx context.Context, sheet *Sheet, start, end int) (err error) {
+	err = s.deleteDimension(ctx, sheet, DimensionColumns, start, end)
+	return
+}
+
+// DuplicateRow inserts count copies of the zero based source row below it.
+// The copies keep the values, formatting and data validation of the source row,
+// and are protected by the protected ranges which protect the source row.
+func (s *Service) DuplicateRow(sheet *Sheet, srcRow, count int) (err error) {
+	err = s.DuplicateRowContext(context.Background(), sheet, srcRow, count)
+	return
+}
+
+// DuplicateRowContext is like DuplicateRow with the context of the request.
+func (s *Service) DuplicateRowContext(ctx context.Context, sheet *Sheet, srcRow, count int) (err error) {
+	err = validateDimensionRange(srcRow, srcRow+count)
+	if err != nil {
+		return
+	}
+	protectedRanges, err := s.fetchProtectedRanges(ctx, sheet)
+	if err != nil {
+		return
+	}
 	r, err := newUpdateRequest(sheet.Spreadsheet)
 	if err != nil {
 		return
 	}
-	err = r.DeleteDimension(sheet, "COLUMNS", start, end).Do()
+	start, end := uint(srcRow+1), uint(srcRow+1+count)
+	r.InsertDimension(sheet, DimensionRows, int(start), int(end))
+	r.CopyPaste(
+		GridRange{SheetID: sheet.Properties.ID, StartRowIndex: uint(srcRow), EndRowIndex: start},
+		GridRange{SheetID: sheet.Properties.ID, StartRowIndex: start, EndRowIndex: end},
+		PasteNormal,
+	)
+	// ranges ending at the source row do not grow by the rows inserted below it
+	for _, protectedRange := range protectedRanges {
+		if protectedRange.Range.EndRowIndex == start && protectedRange.Range.StartRowIndex <= uint(srcRow) {
+			protectedRange.Range.EndRowIndex = end
+			r.UpdateProtectedRange(protectedRange, "range")
+		}
+	}
+	_, err = r.Do(ctx)
+	if err != nil {
+		return
+	}
+	sheet.resizeDimension(DimensionRows, int(start), int(end), true)
+	sheet.mu.Lock()
+	defer sheet.mu.Unlock()
+	if srcRow < len(sheet.Rows) {
+		for row := start; row < end && (sheet.sparse || int(row) < len(sheet.Rows)); row++ {
+			for column, cell := range sheet.Rows[srcRow] {
+				if sheet.sparse {
+					if cell.isEmpty() {
+						continue
+					}
+					sheet.Rows, sheet.Columns = growCells(sheet.Rows, sheet.Columns, row, uint(column))
+				}
+				cell.Row = row
+				sheet.Rows[row][column] = cell
+				sheet.Columns[column][row] = cell
+			}
+		}
+	}
 	return
 }
 
-func (s *Service) syncCells(sheet *Sheet) (err error) {
-	path := fmt.Sprintf("/spreadsheets/%s/values:batchUpdate", sheet.Spreadsheet.ID)
-	params := map[string]interface{}{
-		"valueInputOption": "USER_ENTERED",
-		"data":             make([]map[string]interface{}, 0, len(sheet.modifiedCells)),
+// fetchProtectedRanges fetches the protected ranges of the sheet.
+func (s *Service) fetchProtectedRanges(ctx context.Context, sheet *Sheet) (protectedRanges []ProtectedRange, err error) {
+	params := url.Values{
+		"fields": {"sheets(properties.sheetId,protectedRanges(protectedRangeId,range))"},
+		"ranges": {quoteSheetTitle(sheet.Properties.Title)},
 	}
-	for _, cell := range sheet.modifiedCells {
-		valueRange := map[string]interface{}{
-			"range":          sheet.Properties.Title + "!" + cell.Pos(),
-			"majorDimension": "COLUMNS",
-			"values": [][]string{
-				[]string{
-					cell.Value,
-				},
-			},
+	body, err := s.get(ctx, fmt.Sprintf("/spreadsheets/%s?%s", sheet.Spreadsheet.ID, params.Encode()))
+	if err != nil {
+		return
+	}
+	var resp struct {
+		Sheets []struct {
+			Properties      SheetProperties  `json:"properties"`
+			ProtectedRanges []ProtectedRange `json:"protectedRanges"`
+		} `json:"sheets"`
+	}
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return
+	}
+	for _, data := range resp.Sheets {
+		if data.Properties.ID == sheet.Properties.ID {
+			protectedRanges = data.ProtectedRanges
 		}
-		params["data"] = append(params["data"].([]map[string]interface{}), valueRange)
 	}
-	_, err = sheet.Spreadsheet.service.post(path, params)
 	return
 }
 
-func (s *Service) get(path string) (body []byte, err error) {
-	resp, err := s.client.Get(baseURL + path)
+func (s *Service) insertDimension(ctx context.Context, sheet *Sheet, dimension Dimension, start, end int) (err error) {
+	err = validateDimensionRange(start, end)
 	if err != nil {
 		return
 	}
-	body, err = ioutil.ReadAll(resp.Body)
-	resp.Body.Close()
+	r, err := newUpdateRequest(sheet.Spreadsheet)
 	if err != nil {
 		return
 	}
-	err = s.checkError(body)
+	_, err = r.InsertDimension(sheet, dimension, start, end).Do(ctx)
+	if err != nil {
+		return
+	}
+	sheet.resizeDimension(dimension, start, end, true)
 	return
 }
 
-func (s *Service) post(path string, params map[string]interface{}) (body string, err error) {
-	reqBody, err := json.Marshal(params)
+func (s *Service) deleteDimension(ctx context.Context, sheet *Sheet, dimension Dimension, start, end int) (err error) {
+	err = validateDimensionRange(start, end)
 	if err != nil {
 		return
 	}
-	resp, err := s.client.Post(baseURL+path, "application/json", bytes.NewReader(reqBody))
+	r, err := newUpdateRequest(sheet.Spreadsheet)
 	if err != nil {
 		return
 	}
-	bytes, err := ioutil.ReadAll(resp.Body)
-	resp.Body.Close()
+	warnings := deletionWarnings(sheet, dimension, start, end)
+	_, err = r.DeleteDimension(sheet, dimension, start, end).Do(ctx)
+	if err != nil {
+		return
+	}
+	for _, w := range warnings {
+		s.warn(w)
+	}
+	sheet.resizeDimension(dimension, start, end, false)
+	return
+}
+
+// syncCells sends the cells in batches of value ranges within the limits of the options.
+func (s *Service) syncCells(ctx context.Context, sheet *Sheet, cells []*Cell, t *transfer, o *callOptions) (ranges int, err error) {
+	path := fmt.Sprintf("/spreadsheets/%s/values:batchUpdate", sheet.Spreadsheet.ID)
+	var translator *FormulaTranslator
+	if s.translateFormulas {
+		translator = sheet.Spreadsheet.FormulaTranslator()
+	}
+	majorDimension := o.majorDimensionOrRows()
+	maxCells, maxBytes := o.syncBatchLimit()
+	blocks := cellBlocks(cellRuns(cells, majorDimension), majorDimension)
+	var batches [][]map[string]interface{}
+	var batchCells, batchBytes int
+	for _, block := range splitBlocks(blocks, maxCells) {
+		values := make([][]interface{}, len(block))
+		for i, run := range block {
+			values[i] = make([]interface{}, len(run))
+			for j, cell := range run {
+				values[i][j] = cell.input()
+				if w, ok := valueWarning(sheet, cell, o.valueInputOption); ok {
+					s.warn(w)
+				}
+				if v, ok := values[i][j].(string); ok && translator != nil {
+					values[i][j] = translator.ToLocale(v)
+				}
+			}
+		}
+		rows, columns := uint(len(block)), uint(len(block[0]))
+		if majorDimension == DimensionColumns {
+			rows, columns = columns, rows
+		}
+		first := block[0][0]
+		valueRange := map[string]interface{}{
+			"range":          sheet.a1Range(cellRange(first.Row, first.Column, rows, columns)),
+			"majorDimension": majorDimension,
+			"values":         values,
+		}
+		var data []byte
+		data, err = json.Marshal(valueRange)
+		if err != nil {
+			return
+		}
+		n := int(rows * columns)
+		if len(batches) == 0 || batchCells+n > maxCells || batchBytes+len(data) > maxBytes {
+			batches = append(batches, nil)
+			batchCells, batchBytes = 0, 0
+		}
+		batches[len(batches)-1] = append(batches[len(batches)-1], valueRange)
+		batchCells += n
+		batchBytes += len(data)
+		ranges++
+	}
+	if len(batches) == 0 {
+		batches = append(batches, []map[string]interface{}{})
+	}
+	for _, batch := range batches {
+		_, err = s.send(ctx, t, http.MethodPost, path, map[string]interface{}{
+			"valueInputOption": o.valueInputOption,
+			"data":             batch,
+		})
+		if err != nil {
+			return
+		}
+	}
+	s.usage.cells(0, len(cells))
+	return
+}
+
+// splitBlocks splits the blocks of more than maxCells cells into blocks of whole runs,
+// or into parts of a run when the run alone is longer.
+func splitBlocks(blocks [][][]*Cell, maxCells int) (split [][][]*Cell) {
+	for _, block := range blocks {
+		if len(block)*len(block[0]) <= maxCells {
+			split = append(split, block)
+			continue
+		}
+		runs := maxCells / len(block[0])
+		if runs == 0 {
+			for _, run := range block {
+				for start := 0; start < len(run); start += maxCells {
+					end := start + maxCells
+					if end > len(run) {
+						end = len(run)
+					}
+					split = append(split, [][]*Cell{run[start:end]})
+				}
+			}
+			continue
+		}
+		for start := 0; start < len(block); start += runs {
+			end := start + runs
+			if end > len(block) {
+				end = len(block)
+			}
+			split = append(split, block[start:end])
+		}
+	}
+	return
+}
+
+// cellBlocks merges the runs of cellRuns which span the same cells of adjacent rows, or columns,
+// into rectangular blocks, so that a block of cells is sent as a single range.
+func cellBlocks(runs [][]*Cell, majorDimension Dimension) (blocks [][][]*Cell) {
+	key := func(cell *Cell) (major, minor uint) {
+		if majorDimension == DimensionColumns {
+			return cell.Column, cell.Row
+		}
+		return cell.Row, cell.Column
+	}
+	type span struct {
+		start, length uint
+	}
+	// last are the blocks by the span of their last run, which the run of the next major index may extend.
+	last := map[span]int{}
+	for _, run := range runs {
+		major, start := key(run[0])
+		s := span{start, uint(len(run))}
+		if i, ok := last[s]; ok {
+			block := blocks[i]
+			if prevMajor, _ := key(block[len(block)-1][0]); prevMajor+1 == major {
+				blocks[i] = append(block, run)
+				continue
+			}
+		}
+		last[s] = len(blocks)
+		blocks = append(blocks, [][]*Cell{run})
+	}
+	return
+}
+
+// cellRuns groups the cells into runs of adjacent cells along the major dimension,
+// i.e. parts of rows for DimensionRows and parts of columns for DimensionColumns.
+func cellRuns(cells []*Cell, majorDimension Dimension) (runs [][]*Cell) {
+	key := func(cell *Cell) (major, minor uint) {
+		if majorDimension == DimensionColumns {
+			return cell.Column, cell.Row
+		}
+		return cell.Row, cell.Column
+	}
+	sorted := make([]*Cell, len(cells))
+	copy(sorted, cells)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		mi, ni := key(sorted[i])
+		mj, nj := key(sorted[j])
+		return mi < mj || mi == mj && ni < nj
+	})
+	for i, cell := range sorted {
+		if i > 0 {
+			prevMajor, prevMinor := key(sorted[i-1])
+			major, minor := key(cell)
+			if major == prevMajor && minor == prevMinor+1 {
+				runs[len(runs)-1] = append(runs[len(runs)-1], cell)
+				continue
+			}
+		}
+		runs = append(runs, []*Cell{cell})
+	}
+	return
+}
+
+func (s *Service) get(ctx context.Context, path string) (body []byte, err error) {
+	body, err = s.getURL(ctx, s.baseURL+path)
+	return
+}
+
+// getStream gets the path and decodes the body of the response as it's read, without buffering it whole.
+func (s *Service) getStream(ctx context.Context, path string, decode func(body io.Reader) error) (err error) {
+	_, err = s.doStream(ctx, nil, http.MethodGet, s.baseURL+path, nil, decode)
+	return
+}
+
+func (s *Service) getURL(ctx context.Context, url string) (body []byte, err error) {
+	body, err = s.do(ctx, nil, http.MethodGet, url, nil)
+	return
+}
+
+func (s *Service) post(ctx context.Context, path string, params map[string]interface{}) (body string, err error) {
+	body, err = s.send(ctx, nil, http.MethodPost, path, params)
+	return
+}
+
+func (s *Service) put(ctx context.Context, path string, params map[string]interface{}) (body string, err error) {
+	body, err = s.send(ctx, nil, http.MethodPut, path, params)
+	return
+}
+
+// transfer counts the API calls made and the bytes sent and received by them.
+type transfer struct {
+	calls int
+	bytes int
+}
+
+func (t *transfer) add(sent, received int) {
+	if t == nil {
+		return
+	}
+	t.calls++
+	t.bytes += sent + received
+}
+
+func (s *Service) send(ctx context.Context, t *transfer, method, path string, params map[string]interface{}) (body string, err error) {
+	reqBody, err := json.Marshal(params)
 	if err != nil {
 		return
 	}
-	err = s.checkError(bytes)
+	bytes, err := s.do(ctx, t, method, s.baseURL+path, reqBody)
 	if err != nil {
 		return
 	}
@@ -381,19 +1373,158 @@ func (s *Service) post(path string, params map[string]interface{}) (body string,
 	return
 }
 
-func (s *Service) checkError(body []byte) (err error) {
-	var res map[string]interface{}
-	err = json.Unmarshal(body, &res)
+// do sends the request with the JSON body, if any, and reads the body of the response.
+// A status other than 2xx is returned as an *APIError.
+// The request is sent again by the retry policy of the service while the status is retryable,
+// waiting for the Retry-After of the response when it is longer than the backoff.
+// Every attempt waits for the rate limit of the service, all of them in the span of the call.
+// In dry-run mode, the mutations are recorded instead and get an empty JSON object.
+func (s *Service) do(ctx context.Context, t *transfer, method, url string, reqBody []byte) (body []byte, err error) {
+	body, err = s.doStream(ctx, t, method, url, reqBody, nil)
+	return
+}
+
+// doStream is like do, but a 2xx response is passed to decode as it's read instead of returned, if decode isn't nil.
+func (s *Service) doStream(ctx context.Context, t *transfer, method, url string, reqBody []byte, decode func(body io.Reader) error) (body []byte, err error) {
+	if s.skipDryRun(method, url, reqBody) {
+		body = []byte("{}")
+		if decode != nil {
+			err = decode(bytes.NewReader(body))
+		}
+		return
+	}
+	// a request sent outside of a call is a call of its own
+	ctx, cancel := s.withCallOptions(ctx, nil)
+	defer cancel()
+	var status int
+	idempotent := isIdempotent(method, url)
+	ctx, span := s.startSpan(ctx, method, url)
+	defer func() {
+		span.End(status, err)
+	}()
+	for attempt := 1; ; attempt++ {
+		if err = s.rateLimiter.wait(ctx); err != nil {
+			return
+		}
+		var header http.Header
+		status, header, body, err = s.roundTrip(ctx, t, method, url, reqBody, decode)
+		if err != nil {
+			return
+		}
+		apiErr := checkResponse(status, header, body, time.Now())
+		if apiErr == nil {
+			return
+		}
+		err = apiErr
+		if !retryableStatus(status, idempotent) || attempt >= s.retryPolicy.MaxAttempts {
+			return
+		}
+		s.usage.retry()
+		s.observeRetry(method, url, status)
+		wait := s.retryPolicy.backoff(attempt)
+		if apiErr.RetryAfter > wait {
+			wait = apiErr.RetryAfter
+		}
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// readBody reads the body of the response, decompressing it when gzipped.
+// The transport doesn't decompress it itself as the request sets Accept-Encoding.
+func readBody(resp *http.Response) (body []byte, err error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		body, err = ioutil.ReadAll(resp.Body)
+		return
+	}
+	r, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return
+	}
+	defer r.Close()
+	body, err = ioutil.ReadAll(r)
+	return
+}
+
+// streamBody passes the body of the response to decode as it's read and returns the number of bytes read.
+func streamBody(resp *http.Response, decode func(body io.Reader) error) (n int, err error) {
+	var r io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		var gz *gzip.Reader
+		gz, err = gzip.NewReader(resp.Body)
+		if err != nil {
+			return
+		}
+		defer gz.Close()
+		r = gz
+	}
+	counter := &countingReader{Reader: r}
+	err = decode(counter)
+	if err == nil {
+		_, err = io.Copy(ioutil.Discard, counter)
+	}
+	n = counter.n
+	return
+}
+
+// countingReader counts the bytes read.
+type countingReader struct {
+	io.Reader
+	n int
+}
+
+func (r *countingReader) Read(p []byte) (n int, err error) {
+	n, err = r.Reader.Read(p)
+	r.n += n
+	return
+}
+
+// roundTrip sends the request once and reads the body of the response, or passes it to decode
+// as it's read when the status is 2xx, except in debug mode where the body is logged.
+func (s *Service) roundTrip(ctx context.Context, t *transfer, method, url string, reqBody []byte, decode func(body io.Reader) error) (status int, header http.Header, body []byte, err error) {
+	var reader io.Reader
+	if reqBody != nil {
+		reader = bytes.NewReader(reqBody)
+	}
+	req, err := http.NewRequest(method, url, reader)
+	if err != nil {
+		return
+	}
+	if reqBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("User-Agent", userAgent)
+	start := time.Now()
+	var received int
+	defer func() {
+		d := time.Since(start)
+		s.logCall(method, req.URL.RequestURI(), reqBody, status, body, d, err)
+		s.observeRequest(method, url, status, d, len(reqBody), received)
+	}()
+	resp, err := s.client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
-	resErr, hasErr := res["error"].(map[string]interface{})
-	if !hasErr {
+	status, header = resp.StatusCode, resp.Header
+	if decode != nil && status >= 200 && status <= 299 && !s.debug {
+		received, err = streamBody(resp, decode)
+	} else {
+		body, err = readBody(resp)
+		received = len(body)
+		if err == nil && decode != nil && status >= 200 && status <= 299 {
+			err = decode(bytes.NewReader(body))
+		}
+	}
+	resp.Body.Close()
+	s.usage.call(method, url, time.Since(start))
+	if err != nil {
 		return
 	}
-	code := resErr["code"].(float64)
-	message := resErr["message"].(string)
-	status := resErr["status"].(string)
-	err = fmt.Errorf("error status: %s, code:%d, message: %s", status, int(code), message)
+	t.add(len(reqBody), received)
 	return
 }
@@ -1,8 +1,20 @@
 package spreadsheet
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"regexp"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -16,7 +28,7 @@ type TestSuite struct {
 
 func (suite *TestSuite) SetupSuite() {
 	var err error
-	suite.service, err = NewService()
+	suite.service, err = NewService(context.Background())
 	suite.Require().NoError(err)
 }
 
@@ -69,7 +81,7 @@ func (suite *TestSuite) TestFetchSpreadsheet() {
 	suite.Equal(spreadsheetID, spreadsheet.ID)
 	suite.Require().Equal(2, len(spreadsheet.Sheets))
 
-	sheet := spreadsheet.Sheets[0]
+	sheet := &spreadsheet.Sheets[0]
 	suite.Equal(uint(0), sheet.Properties.ID)
 	suite.Equal("TestSheet", sheet.Properties.Title)
 	suite.Equal(uint(0), sheet.Properties.Index)
@@ -110,8 +122,27 @@ func (suite *TestSuite) TestSyncSheet() {
 	sheet, err := spreadsheet.SheetByTitle("TestSheet")
 	suite.Require().NoError(err)
 	sheet.Update(1, 6, "=SUM(D1:D2)")
-	err = suite.service.SyncSheet(sheet)
+	result, err := suite.service.SyncSheet(sheet)
 	suite.NoError(err)
+	suite.Equal(1, result.CellsUpdated)
+	suite.Equal(1, result.RangesSent)
+	suite.True(result.APICalls >= 1)
+	suite.True(result.BytesTransferred > 0)
+}
+
+func (suite *TestSuite) TestUpdateRangeTransposed() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet")
+	suite.Require().NoError(err)
+	err = sheet.UpdateRangeTransposed(5, 0, [][]interface{}{
+		{"a", "b", "c"},
+		{1, 2, 3},
+	})
+	suite.Require().NoError(err)
+	columns, err := sheet.GetColumns("A6:B8")
+	suite.Require().NoError(err)
+	suite.Equal([][]string{{"a", "b", "c"}, {"1", "2", "3"}}, columns)
 }
 
 func (suite *TestSuite) TestDeleteRows() {
@@ -126,6 +157,351 @@ func (suite *TestSuite) TestDeleteRows() {
 	suite.Equal(rowCount-1, sheet.Properties.GridProperties.RowCount)
 }
 
+func (suite *TestSuite) TestGetValues() {
+	valueRange, err := suite.service.GetValues(spreadsheetID, "TestSheet!A1:C3")
+	suite.Require().NoError(err)
+	suite.Equal("TestSheet!A1:C3", valueRange.Range)
+	suite.Equal(DimensionRows, valueRange.MajorDimension)
+	suite.True(len(valueRange.Values) <= 3)
+}
+
+func (suite *TestSuite) TestGetValuesWithValueRenderOption() {
+	valueRange, err := suite.service.GetValues(spreadsheetID, "TestSheet!G2", WithValueRenderOption(ValueRenderFormula))
+	suite.Require().NoError(err)
+	suite.Equal([][]interface{}{{"=SUM(D1:D2)"}}, valueRange.Values)
+
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID, WithValueRenderOption(ValueRenderFormula))
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet")
+	suite.Require().NoError(err)
+	value, err := sheet.Value(1, 6)
+	suite.Require().NoError(err)
+	suite.Equal("=SUM(D1:D2)", value)
+}
+
+func (suite *TestSuite) TestBatchGetValues() {
+	valueRanges, err := suite.service.BatchGetValues(spreadsheetID, "TestSheet!A1:B2", "TestSheet2!A1")
+	suite.Require().NoError(err)
+	suite.Require().Len(valueRanges, 2)
+	suite.Equal("TestSheet!A1:B2", valueRanges[0].Range)
+	suite.Equal("TestSheet2!A1", valueRanges[1].Range)
+}
+
+func (suite *TestSuite) TestUpdateValues() {
+	resp, err := suite.service.UpdateValues(spreadsheetID, "TestSheet!E6:F7", [][]interface{}{
+		{"=1+1", "003"},
+		{"x", 4},
+	}, WithValueInputOption(ValueInputRaw))
+	suite.Require().NoError(err)
+	suite.Equal(4, resp.UpdatedCells)
+	valueRange, err := suite.service.GetValues(spreadsheetID, "TestSheet!E6:F6")
+	suite.Require().NoError(err)
+	suite.Equal([][]interface{}{{"=1+1", "003"}}, valueRange.Values)
+}
+
+func (suite *TestSuite) TestAppendValues() {
+	resp, err := suite.service.AppendValues(spreadsheetID, "TestSheet2!A1", [][]interface{}{
+		{"appended", 1},
+	}, WithInsertDataOption(InsertDataInsertRows))
+	suite.Require().NoError(err)
+	suite.Equal(spreadsheetID, resp.SpreadsheetID)
+	suite.Equal(2, resp.Updates.UpdatedCells)
+	suite.NotEmpty(resp.Updates.UpdatedRange)
+}
+
+func (suite *TestSuite) TestAtomicAppend() {
+	ref := SheetRef{SpreadsheetID: spreadsheetID, Title: "TestSheet2"}
+	var wg sync.WaitGroup
+	ranges := make([]string, 3)
+	for i := range ranges {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := suite.service.AtomicAppend(ref, [][]interface{}{{"atomic", i}})
+			suite.NoError(err)
+			ranges[i] = resp.Updates.UpdatedRange
+		}(i)
+	}
+	wg.Wait()
+	suite.NotEqual(ranges[0], ranges[1])
+	suite.NotEqual(ranges[1], ranges[2])
+	suite.NotEqual(ranges[0], ranges[2])
+}
+
+func (suite *TestSuite) TestClearValues() {
+	_, err := suite.service.UpdateValues(spreadsheetID, "TestSheet2!H1:H2", [][]interface{}{{"a"}, {"b"}})
+	suite.Require().NoError(err)
+	resp, err := suite.service.ClearValues(spreadsheetID, "TestSheet2!H1:H2")
+	suite.Require().NoError(err)
+	suite.Equal("TestSheet2!H1:H2", resp.ClearedRange)
+	valueRange, err := suite.service.GetValues(spreadsheetID, "TestSheet2!H1:H2")
+	suite.Require().NoError(err)
+	suite.Empty(valueRange.Values)
+}
+
+func (suite *TestSuite) TestBatchClearValues() {
+	resp, err := suite.service.BatchClearValues(spreadsheetID, "TestSheet2!H1", "TestSheet2!J1:J2")
+	suite.Require().NoError(err)
+	suite.Equal([]string{"TestSheet2!H1", "TestSheet2!J1:J2"}, resp.ClearedRanges)
+}
+
+func (suite *TestSuite) TestBatchGetValuesByDataFilter() {
+	valueRanges, err := suite.service.BatchGetValuesByDataFilter(spreadsheetID,
+		DataFilter{A1Range: "TestSheet!A1:B2"},
+		DataFilter{GridRange: &GridRange{SheetID: 0, EndRowIndex: 1, EndColumnIndex: 1}},
+	)
+	suite.Require().NoError(err)
+	suite.Require().Len(valueRanges, 2)
+	suite.Equal("TestSheet!A1:B2", valueRanges[0].DataFilters[0].A1Range)
+}
+
+func (suite *TestSuite) TestBatchUpdateValuesByDataFilter() {
+	resp, err := suite.service.BatchUpdateValuesByDataFilter(spreadsheetID, []DataFilterValueRange{
+		{
+			DataFilter: DataFilter{A1Range: "TestSheet2!K1:L1"},
+			Values:     [][]interface{}{{"k", "l"}},
+		},
+	})
+	suite.Require().NoError(err)
+	suite.Equal(2, resp.TotalUpdatedCells)
+	suite.Require().Len(resp.Responses, 1)
+	suite.Equal("TestSheet2!K1:L1", resp.Responses[0].UpdatedRange)
+}
+
+func (suite *TestSuite) TestBatchClearValuesByDataFilter() {
+	resp, err := suite.service.BatchClearValuesByDataFilter(spreadsheetID, DataFilter{A1Range: "TestSheet2!K1:L1"})
+	suite.Require().NoError(err)
+	suite.Equal([]string{"TestSheet2!K1:L1"}, resp.ClearedRanges)
+}
+
+func (suite *TestSuite) TestGetColumns() {
+	columns, err := suite.service.GetColumns(spreadsheetID, "TestSheet!A1:C3")
+	suite.Require().NoError(err)
+	suite.True(len(columns) <= 3)
+	for _, column := range columns {
+		suite.True(len(column) <= 3)
+	}
+}
+
+func (suite *TestSuite) TestStreamCSV() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	testSheet, err := spreadsheet.SheetByTitle("TestSheet")
+	suite.Require().NoError(err)
+	var buf bytes.Buffer
+	err = testSheet.StreamCSV(context.Background(), &buf)
+	suite.Require().NoError(err)
+	suite.NotEmpty(buf.String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = testSheet.StreamCSV(ctx, &buf)
+	suite.Equal(context.Canceled, err)
+}
+
+func (suite *TestSuite) TestUpdateValuesInResponse() {
+	resp, err := suite.service.UpdateValues(spreadsheetID, "TestSheet2!L1", [][]interface{}{{"=1+1"}},
+		WithValuesInResponse(ValueRenderUnformatted))
+	suite.Require().NoError(err)
+	suite.Equal(1, resp.UpdatedCells)
+	suite.Require().NotNil(resp.UpdatedData)
+	suite.Equal([][]interface{}{{float64(2)}}, resp.UpdatedData.Values)
+}
+
+func (suite *TestSuite) TestFetchSpreadsheetRanges() {
+	spreadsheet, err := suite.service.FetchSpreadsheetRanges(spreadsheetID, []string{"TestSheet!B2:C3"})
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet")
+	suite.Require().NoError(err)
+	suite.Equal(uint(1), sheet.Data.GridData[0].StartRow)
+	suite.Equal(uint(1), sheet.Data.GridData[0].StartColumn)
+	suite.Equal("", sheet.Rows[0][0].Value)
+
+	_, err = suite.service.FetchSpreadsheetRanges(spreadsheetID, nil)
+	suite.Error(err)
+}
+
+func (suite *TestSuite) TestFetchSpreadsheetWithOptions() {
+	spreadsheet, err := suite.service.FetchSpreadsheetWithOptions(spreadsheetID, FetchOptions{
+		Ranges: []string{"TestSheet!A1:B2"},
+		Fields: "spreadsheetId,sheets(properties,data.rowData.values(formattedValue,note))",
+	})
+	suite.Require().NoError(err)
+	suite.Equal(spreadsheetID, spreadsheet.ID)
+	suite.Empty(spreadsheet.Properties.Title)
+	suite.NotEmpty(spreadsheet.Sheets)
+
+	spreadsheet, err = suite.service.FetchSpreadsheetWithOptions(spreadsheetID, FetchOptions{})
+	suite.Require().NoError(err)
+	suite.NotEmpty(spreadsheet.Properties.Title)
+	suite.Empty(spreadsheet.Sheets[0].Data.GridData)
+}
+
+func (suite *TestSuite) TestDeleteSheetsMatching() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	for _, title := range []string{"report-2019-01-01", "report-2019-01-02"} {
+		err = suite.service.AddSheet(&spreadsheet, SheetProperties{Title: title})
+		suite.Require().NoError(err)
+	}
+	deleted, err := suite.service.DeleteSheetsMatching(&spreadsheet, regexp.MustCompile(`^report-2019-`))
+	suite.Require().NoError(err)
+	suite.Equal([]string{"report-2019-01-01", "report-2019-01-02"}, deleted)
+	_, err = spreadsheet.SheetByTitle("report-2019-01-01")
+	suite.Error(err)
+
+	_, err = suite.service.DeleteSheetsMatching(&spreadsheet, regexp.MustCompile(`.*`))
+	suite.Error(err)
+}
+
+func (suite *TestSuite) TestFetchSpreadsheetByDataFilter() {
+	spreadsheet, err := suite.service.FetchSpreadsheetByDataFilter(spreadsheetID, []DataFilter{
+		{A1Range: "TestSheet!A1:B2"},
+	})
+	suite.Require().NoError(err)
+	suite.Equal(spreadsheetID, spreadsheet.ID)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet")
+	suite.Require().NoError(err)
+	suite.NotEmpty(sheet.Data.GridData)
+
+	_, err = suite.service.FetchSpreadsheetByDataFilter(spreadsheetID, nil)
+	suite.Error(err)
+}
+
+func (suite *TestSuite) TestCopySheetTo() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet")
+	suite.Require().NoError(err)
+	properties, err := suite.service.CopySheetTo(spreadsheetID, sheet.Properties.ID, spreadsheetID)
+	suite.Require().NoError(err)
+	suite.NotEqual(sheet.Properties.ID, properties.ID)
+	suite.Contains(properties.Title, "TestSheet")
+	err = suite.service.DeleteSheet(&spreadsheet, properties.ID)
+	suite.Require().NoError(err)
+}
+
+func (suite *TestSuite) TestGetDeveloperMetadata() {
+	_, err := suite.service.GetDeveloperMetadata(spreadsheetID, 987654321)
+	suite.Error(err)
+}
+
+func (suite *TestSuite) TestSearchDeveloperMetadata() {
+	matched, err := suite.service.SearchDeveloperMetadata(spreadsheetID, DataFilter{
+		DeveloperMetadataLookup: &DeveloperMetadataLookup{MetadataKey: "no-such-key"},
+	})
+	suite.Require().NoError(err)
+	suite.Empty(matched)
+
+	_, err = suite.service.SearchDeveloperMetadata(spreadsheetID)
+	suite.Error(err)
+}
+
+func (suite *TestSuite) TestFetchSpreadsheetMetadata() {
+	spreadsheet, err := suite.service.FetchSpreadsheetMetadata(spreadsheetID)
+	suite.Require().NoError(err)
+	suite.Equal(spreadsheetID, spreadsheet.ID)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet")
+	suite.Require().NoError(err)
+	suite.NotZero(sheet.Properties.GridProperties.RowCount)
+	suite.Empty(sheet.Data.GridData)
+}
+
+func (suite *TestSuite) TestLoadData() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID, WithLazyLoading())
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet")
+	suite.Require().NoError(err)
+	suite.Empty(sheet.Data.GridData)
+	err = sheet.LoadData(context.Background())
+	suite.Require().NoError(err)
+	suite.NotEmpty(sheet.Data.GridData)
+}
+
+func (suite *TestSuite) TestDuplicateRow() {
+	spreadsheet, err := suite.service.FetchSpreadsheet(spreadsheetID)
+	suite.Require().NoError(err)
+	sheet, err := spreadsheet.SheetByTitle("TestSheet2")
+	suite.Require().NoError(err)
+	sheet.Update(0, 0, "duplicated")
+	err = sheet.Synchronize()
+	suite.Require().NoError(err)
+	rowCount := sheet.Properties.GridProperties.RowCount
+	err = sheet.DuplicateRow(0, 2)
+	suite.Require().NoError(err)
+	suite.Equal(rowCount+2, sheet.Properties.GridProperties.RowCount)
+	suite.Equal("duplicated", sheet.Rows[2][0].Value)
+	err = sheet.DeleteRows(1, 3)
+	suite.Require().NoError(err)
+}
+
+func (suite *TestSuite) TestListSpreadsheets() {
+	files, next, err := suite.service.ListSpreadsheets("", 1)
+	suite.Require().NoError(err)
+	suite.Len(files, 1)
+	if next == "" {
+		return
+	}
+	again, _, err := suite.service.ListSpreadsheets(next, 1)
+	suite.Require().NoError(err)
+	suite.NotEqual(files, again)
+}
+
+func TestNewService(t *testing.T) {
+	assert := assert.New(t)
+	client := &http.Client{}
+	policy := RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond}
+	s, err := NewService(context.Background(),
+		WithHTTPClient(client),
+		WithRetryPolicy(policy),
+		WithRateLimit(60, 1),
+		WithBaseURL("http://localhost:8080/v4"),
+	)
+	assert.NoError(err)
+	assert.True(client == s.client)
+	assert.Equal(policy, s.retryPolicy)
+	assert.Equal(time.Second, s.rateLimiter.interval)
+	assert.Equal("http://localhost:8080/v4", s.baseURL)
+
+	assert.Equal([]string{SpreadsheetsScope}, newServiceSettings(nil).scopes)
+	assert.Equal(ReadOnlyScopes, newServiceSettings([]ServiceOption{WithScopes(ReadOnlyScopes...)}).scopes)
+
+	_, err = NewService(context.Background(), WithCredentialsFile("testdata/missing.json"))
+	assert.Error(err)
+
+	_, err = NewServiceFromJSON(context.Background(), []byte(""))
+	assert.EqualError(err, "service account JSON must not be empty")
+	_, err = NewServiceFromJSON(context.Background(), []byte("{}"))
+	assert.Error(err)
+	_, err = NewServiceFromJSON(context.Background(), serviceAccountJSON(t, []byte("not a key")))
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "malformed private key of the service account")
+	}
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if assert.NoError(err) {
+		block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+		_, err = NewServiceFromJSON(context.Background(), serviceAccountJSON(t, pem.EncodeToMemory(block)))
+		assert.NoError(err)
+	}
+
+	_, err = NewServiceWithSubject(context.Background(), "user@example.com", WithHTTPClient(client))
+	assert.EqualError(err, "the subject needs the credentials of a service account")
+}
+
+// serviceAccountJSON returns the JSON of a service account with the private key.
+func serviceAccountJSON(t *testing.T, privateKey []byte) []byte {
+	data, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "test@example.iam.gserviceaccount.com",
+		"private_key":  string(privateKey),
+		"token_uri":    "https://oauth2.googleapis.com/token",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
 func TestRun(t *testing.T) {
 	suite.Run(t, new(TestSuite))
 }
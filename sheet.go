@@ -1,36 +1,80 @@
 package spreadsheet
 
-import "encoding/json"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
 
 // Sheet is a sheet in a spreadsheet.
+// Its cells can be updated and read by Update and Cell from multiple goroutines, e.g. a pool of workers,
+// and synchronized meanwhile; Rows and Columns must not be accessed directly then.
 type Sheet struct {
 	Properties SheetProperties `json:"properties"`
 	Data       SheetData       `json:"data"`
 	TmpData    []byte          `json:"tmpdata"`
-	// Merges []*GridRange `json:"merges"`
-	// ConditionalFormats []*ConditionalFormatRule `json:"conditionalFormats"`
-	// FilterViews []*FilterView `json:"filterViews"`
-	// ProtectedRanges []*ProtectedRange `json:"protectedRanges"`
-	// BasicFilter *BasicFilter `json:"basicFilter"`
-	// Charts []*EmbeddedChart `json:"charts"`
-	// BandedRanges []*BandedRange `json:"bandedRanges"`
 
 	Spreadsheet *Spreadsheet `json:"-"`
 	Rows        [][]Cell     `json:"-"`
 	Columns     [][]Cell     `json:"-"`
 
+	// expandMu serializes the expansions of the grid.
+	expandMu sync.Mutex
+	// mu guards the cells, the grid size and the changes not synchronized yet.
+	mu            sync.Mutex
 	modifiedCells []*Cell
 	newMaxRow     uint
 	newMaxColumn  uint
+	syncHooks     []SyncHooks
+	boundsPolicy  BoundsPolicy
+	// sparse keeps the rows and the columns trimmed after their last non-empty cell, see WithSparseCells.
+	sparse             bool
+	merges             []GridRange
+	conditionalFormats []ConditionalFormatRule
+	protectedRanges    []ProtectedRange
+	charts             []EmbeddedChart
+	bandedRanges       []BandedRange
+	filterViews        []FilterView
+	basicFilter        *BasicFilter
 }
 
 // UnmarshalJSON embeds rows and columns to the sheet.
 func (sheet *Sheet) UnmarshalJSON(data []byte) error {
 	type Alias Sheet
-	a := (*Alias)(sheet)
-	if err := json.Unmarshal(data, a); err != nil {
+	a := struct {
+		*Alias
+		Merges             []GridRange             `json:"merges"`
+		ConditionalFormats []ConditionalFormatRule `json:"conditionalFormats"`
+		ProtectedRanges    []ProtectedRange        `json:"protectedRanges"`
+		Charts             []EmbeddedChart         `json:"charts"`
+		BandedRanges       []BandedRange           `json:"bandedRanges"`
+		FilterViews        []FilterView            `json:"filterViews"`
+		BasicFilter        *BasicFilter            `json:"basicFilter"`
+	}{Alias: (*Alias)(sheet)}
+	if err := json.Unmarshal(data, &a); err != nil {
 		return err
 	}
+	sheet.merges = a.Merges
+	sheet.conditionalFormats = a.ConditionalFormats
+	sheet.protectedRanges = a.ProtectedRanges
+	sheet.charts = a.Charts
+	sheet.bandedRanges = a.BandedRanges
+	sheet.filterViews = a.FilterViews
+	sheet.basicFilter = a.BasicFilter
+	sheet.loadCells()
+	sheet.TmpData = append([]byte(nil), data...)
+	sheet.modifiedCells = []*Cell{}
+	sheet.newMaxRow = sheet.Properties.GridProperties.RowCount
+	sheet.newMaxColumn = sheet.Properties.GridProperties.ColumnCount
+
+	return nil
+}
+
+// loadCells makes the rows and the columns of the cells from the grid data.
+func (sheet *Sheet) loadCells() {
 	var maxRow, maxColumn int
 	cells := []Cell{}
 	for _, gridData := range sheet.Data.GridData {
@@ -45,30 +89,239 @@ func (sheet *Sheet) UnmarshalJSON(data []byte) error {
 					maxColumn = int(c)
 				}
 				cell := Cell{
-					Row:    r,
-					Column: c,
-					Value:  cellData.FormattedValue,
+					Row:              r,
+					Column:           c,
+					Value:            cellData.FormattedValue,
+					UserEnteredValue: cellData.UserEnteredValue.pointer(),
+					FormattedValue:   cellData.FormattedValue,
+					EffectiveValue:   cellData.EffectiveValue.pointer(),
+					Hyperlink:        cellData.Hyperlink,
+				}
+				// sparse sheets only keep the cells with any of the fetched fields
+				if sheet.sparse && cell.isEmpty() {
+					continue
 				}
 				cells = append(cells, cell)
 			}
 		}
 	}
-	sheet.Rows, sheet.Columns = newCells(uint(maxRow), uint(maxColumn))
+	if sheet.sparse {
+		sheet.Rows, sheet.Columns = newSparseCells(cells)
+	} else {
+		// the cells within the grid can be indexed, even empty ones. Sheets fetched without their cells,
+		// e.g. WithLazyLoading, have no grid data and aren't sized until they are loaded.
+		if grid := sheet.Properties.GridProperties; len(sheet.Data.GridData) > 0 {
+			if int(grid.RowCount)-1 > maxRow {
+				maxRow = int(grid.RowCount) - 1
+			}
+			if int(grid.ColumnCount)-1 > maxColumn {
+				maxColumn = int(grid.ColumnCount) - 1
+			}
+		}
+		sheet.Rows, sheet.Columns = newCells(uint(maxRow), uint(maxColumn))
+		for _, cell := range cells {
+			sheet.Rows[cell.Row][cell.Column] = cell
+			sheet.Columns[cell.Column][cell.Row] = cell
+		}
+	}
+}
 
-	for _, cell := range cells {
-		sheet.Rows[cell.Row][cell.Column] = cell
-		sheet.Columns[cell.Column][cell.Row] = cell
+// renderValues sets the values of the cells from the grid data as rendered by the option.
+func (sheet *Sheet) renderValues(option ValueRenderOption) {
+	for _, gridData := range sheet.Data.GridData {
+		for rowNum, row := range gridData.RowData {
+			for columnNum, cellData := range row.Values {
+				r := gridData.StartRow + uint(rowNum)
+				c := gridData.StartColumn + uint(columnNum)
+				var value string
+				switch option {
+				case ValueRenderUnformatted:
+					value = cellData.EffectiveValue.String()
+				case ValueRenderFormula:
+					value = cellData.UserEnteredValue.String()
+				default:
+					value = cellData.FormattedValue
+				}
+				if sheet.sparse {
+					if value == "" && (int(r) >= len(sheet.Rows) || int(c) >= len(sheet.Rows[r])) {
+						continue
+					}
+					sheet.Rows, sheet.Columns = growCells(sheet.Rows, sheet.Columns, r, c)
+				}
+				sheet.Rows[r][c].Value = value
+				sheet.Columns[c][r].Value = value
+			}
+		}
 	}
-	sheet.TmpData = data
-	sheet.modifiedCells = []*Cell{}
-	sheet.newMaxRow = sheet.Properties.GridProperties.RowCount
-	sheet.newMaxColumn = sheet.Properties.GridProperties.ColumnCount
+}
 
+// Cell returns the cell at the zero based row and column.
+// Cells within the grid which have no data are returned empty.
+// Cells outside of the grid return an *OutOfRangeError.
+func (sheet *Sheet) Cell(row, column int) (cell Cell, err error) {
+	sheet.mu.Lock()
+	defer sheet.mu.Unlock()
+	cell, err = sheet.cell(row, column)
+	return
+}
+
+func (sheet *Sheet) cell(row, column int) (cell Cell, err error) {
+	rowCount, columnCount := sheet.gridSize()
+	if row < 0 || column < 0 || uint(row) >= rowCount || uint(column) >= columnCount {
+		err = &OutOfRangeError{
+			Row:         row,
+			Column:      column,
+			RowCount:    rowCount,
+			ColumnCount: columnCount,
+		}
+		return
+	}
+	cell = Cell{Row: uint(row), Column: uint(column)}
+	if row < len(sheet.Rows) && column < len(sheet.Rows[row]) {
+		cell = sheet.Rows[row][column]
+	}
+	return
+}
+
+// Value returns the value of the cell at the zero based row and column.
+// Cells within the grid which have no data have an empty value.
+// Cells outside of the grid return an *OutOfRangeError.
+func (sheet *Sheet) Value(row, column int) (value string, err error) {
+	cell, err := sheet.Cell(row, column)
+	if err != nil {
+		return
+	}
+	value = cell.Value
+	return
+}
+
+// gridSize returns the number of rows and columns of the grid including pending changes.
+func (sheet *Sheet) gridSize() (rows, columns uint) {
+	rows, columns = sheet.Properties.GridProperties.RowCount, sheet.Properties.GridProperties.ColumnCount
+	if sheet.newMaxRow > rows {
+		rows = sheet.newMaxRow
+	}
+	if sheet.newMaxColumn > columns {
+		columns = sheet.newMaxColumn
+	}
+	return
+}
+
+// BoundsPolicy determines what happens when a cell outside of the grid is updated.
+type BoundsPolicy int
+
+const (
+	// BoundsAutoExpand expands the grid to contain the updated cells when the sheet is synchronized.
+	BoundsAutoExpand BoundsPolicy = iota
+	// BoundsStrict rejects updates of cells outside of the grid with an *OutOfRangeError.
+	BoundsStrict
+)
+
+// SetBoundsPolicy sets what happens when a cell outside of the grid is updated.
+// The default is BoundsAutoExpand.
+func (sheet *Sheet) SetBoundsPolicy(policy BoundsPolicy) {
+	sheet.mu.Lock()
+	defer sheet.mu.Unlock()
+	sheet.boundsPolicy = policy
+}
+
+// Update updates cell changes.
+// The value is interpreted by the value input option of the synchronization, e.g. "3" is a number
+// with ValueInputUserEntered, the default, and a text with ValueInputRaw.
+// Updating a cell outside of the grid returns an *OutOfRangeError when the
+// bounds policy of the sheet is BoundsStrict or the position is negative.
+func (sheet *Sheet) Update(row, column int, val string) (err error) {
+	err = sheet.update(row, column, Cell{Value: val})
+	return
+}
+
+// UpdateNumber updates the cell with a number, written as a number whatever the value input option.
+func (sheet *Sheet) UpdateNumber(row, column int, number float64) (err error) {
+	entered := &ExtendedValue{NumberValue: number, kind: "numberValue"}
+	err = sheet.update(row, column, Cell{Value: entered.String(), UserEnteredValue: entered})
+	return
+}
+
+// UpdateBool updates the cell with a boolean, written as a boolean whatever the value input option.
+func (sheet *Sheet) UpdateBool(row, column int, b bool) (err error) {
+	entered := &ExtendedValue{BoolValue: b, kind: "boolValue"}
+	err = sheet.update(row, column, Cell{Value: entered.String(), UserEnteredValue: entered})
+	return
+}
+
+// UpdateFormula updates the cell with a formula starting with "=", e.g. "=SUM(A1:A3)".
+// The formula is written as the formula value of the cell rather than a text parsed as entered in the UI,
+// so it's a formula whatever the value input option, and translated to the locale of the spreadsheet
+// like the values when formula translation is enabled.
+func (sheet *Sheet) UpdateFormula(row, column int, formula string) (err error) {
+	if err = checkFormula(formula); err != nil {
+		return
+	}
+	err = sheet.update(row, column, Cell{Value: formula, UserEnteredValue: &ExtendedValue{FormulaValue: formula}})
+	return
+}
+
+// UpdateFormulas updates the cells of the rows of formulas starting at the row and the column, like UpdateFormula.
+// Empty formulas leave their cells unchanged. No cell is updated when a formula doesn't start with "=".
+func (sheet *Sheet) UpdateFormulas(row, column int, formulas [][]string) (err error) {
+	for _, values := range formulas {
+		for _, formula := range values {
+			if formula == "" {
+				continue
+			}
+			if err = checkFormula(formula); err != nil {
+				return
+			}
+		}
+	}
+	for i, values := range formulas {
+		for j, formula := range values {
+			if formula == "" {
+				continue
+			}
+			if err = sheet.UpdateFormula(row+i, column+j, formula); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func checkFormula(formula string) error {
+	if !strings.HasPrefix(formula, "=") {
+		return fmt.Errorf("formula %q must start with =", formula)
+	}
 	return nil
 }
 
-// Update updates cell changes
-func (sheet *Sheet) Update(row, column int, val string) {
+// UpdateLink updates the cell with a link to the URL displaying the text, or the URL when the text is empty,
+// written as a HYPERLINK formula.
+func (sheet *Sheet) UpdateLink(row, column int, url, text string) (err error) {
+	formula := "=HYPERLINK(" + formulaString(url) + ")"
+	if text == "" {
+		text = url
+	} else {
+		formula = "=HYPERLINK(" + formulaString(url) + "," + formulaString(text) + ")"
+	}
+	err = sheet.update(row, column, Cell{Value: text, UserEnteredValue: &ExtendedValue{FormulaValue: formula}, Hyperlink: url})
+	return
+}
+
+// formulaString quotes the text as a string literal of a formula.
+func formulaString(text string) string {
+	return `"` + strings.Replace(text, `"`, `""`, -1) + `"`
+}
+
+// update updates the cell at the row and the column with the values of the cell.
+func (sheet *Sheet) update(row, column int, cell Cell) (err error) {
+	sheet.mu.Lock()
+	defer sheet.mu.Unlock()
+	if row < 0 || column < 0 || sheet.boundsPolicy == BoundsStrict {
+		_, err = sheet.cell(row, column)
+		if err != nil {
+			return
+		}
+	}
 	if uint(row)+1 > sheet.newMaxRow {
 		sheet.newMaxRow = uint(row) + 1
 	}
@@ -76,7 +329,9 @@ func (sheet *Sheet) Update(row, column int, val string) {
 		sheet.newMaxColumn = uint(column) + 1
 	}
 
-	if uint(len(sheet.Rows)) < sheet.newMaxRow+1 ||
+	if sheet.sparse {
+		sheet.Rows, sheet.Columns = growCells(sheet.Rows, sheet.Columns, uint(row), uint(column))
+	} else if uint(len(sheet.Rows)) < sheet.newMaxRow+1 ||
 		uint(len(sheet.Columns)) < sheet.newMaxColumn+1 {
 		newRows, newColumns := newCells(sheet.newMaxRow, sheet.newMaxColumn)
 		for i := range sheet.Rows {
@@ -89,53 +344,277 @@ func (sheet *Sheet) Update(row, column int, val string) {
 		sheet.Columns = newColumns
 	}
 
-	cell := Cell{
-		Row:    uint(row),
-		Column: uint(column),
-		Value:  val,
-	}
-
+	cell.Row, cell.Column = uint(row), uint(column)
 	sheet.Rows[row][column] = cell
 	sheet.Columns[column][row] = cell
-	for _, cell := range sheet.modifiedCells {
-		if cell.Row == uint(row) && cell.Column == uint(column) {
-			cell.Value = val
+	for _, modified := range sheet.modifiedCells {
+		if modified.Row == cell.Row && modified.Column == cell.Column {
+			*modified = cell
 			return
 		}
 	}
 	sheet.modifiedCells = append(sheet.modifiedCells, &cell)
+	return
+}
+
+// takeChanges removes the changes not synchronized yet from the sheet, for a synchronization
+// to send them while the sheet is still updated, and returns them with the grid size they need.
+func (sheet *Sheet) takeChanges() (cells []*Cell, rows, columns uint) {
+	sheet.mu.Lock()
+	defer sheet.mu.Unlock()
+	cells = sheet.modifiedCells
+	sheet.modifiedCells = []*Cell{}
+	rows, columns = sheet.newMaxRow, sheet.newMaxColumn
+	return
+}
+
+// restoreChanges puts back the changes which failed to be synchronized,
+// except the ones of cells updated again since.
+func (sheet *Sheet) restoreChanges(cells []*Cell) {
+	sheet.mu.Lock()
+	defer sheet.mu.Unlock()
+	updated := make(map[[2]uint]bool, len(sheet.modifiedCells))
+	for _, cell := range sheet.modifiedCells {
+		updated[[2]uint{cell.Row, cell.Column}] = true
+	}
+	restored := make([]*Cell, 0, len(cells)+len(sheet.modifiedCells))
+	for _, cell := range cells {
+		if !updated[[2]uint{cell.Row, cell.Column}] {
+			restored = append(restored, cell)
+		}
+	}
+	sheet.modifiedCells = append(restored, sheet.modifiedCells...)
 }
 
 // AppendCells inserts rows into the sheet
 func (sheet *Sheet) AppendCells(rows [][]Cell) (err error) {
-	err = sheet.Spreadsheet.service.AppendCells(sheet, rows)
+	err = sheet.AppendCellsContext(context.Background(), rows)
+	return
+}
+
+// AppendCellsContext is like AppendCells with the context of the request.
+func (sheet *Sheet) AppendCellsContext(ctx context.Context, rows [][]Cell) (err error) {
+	err = sheet.Spreadsheet.service.AppendCellsContext(ctx, sheet, rows)
 	return
 }
 
 // InsertRows inserts rows into the sheet
 func (sheet *Sheet) InsertRows(start, end int) (err error) {
-	err = sheet.Spreadsheet.service.InsertRows(sheet, start, end)
+	err = sheet.InsertRowsContext(context.Background(), start, end)
+	return
+}
+
+// InsertRowsContext is like InsertRows with the context of the request.
+func (sheet *Sheet) InsertRowsContext(ctx context.Context, start, end int) (err error) {
+	err = sheet.Spreadsheet.service.InsertRowsContext(ctx, sheet, start, end)
+	return
+}
+
+// InsertColumns inserts columns into the sheet
+func (sheet *Sheet) InsertColumns(start, end int) (err error) {
+	err = sheet.InsertColumnsContext(context.Background(), start, end)
+	return
+}
+
+// InsertColumnsContext is like InsertColumns with the context of the request.
+func (sheet *Sheet) InsertColumnsContext(ctx context.Context, start, end int) (err error) {
+	err = sheet.Spreadsheet.service.InsertColumnsContext(ctx, sheet, start, end)
+	return
+}
+
+// AppendColumns appends empty columns to the end of the sheet
+func (sheet *Sheet) AppendColumns(length int) (err error) {
+	err = sheet.AppendColumnsContext(context.Background(), length)
+	return
+}
+
+// AppendColumnsContext is like AppendColumns with the context of the request.
+func (sheet *Sheet) AppendColumnsContext(ctx context.Context, length int) (err error) {
+	err = sheet.Spreadsheet.service.AppendColumnsContext(ctx, sheet, length)
+	return
+}
+
+// HideColumns hides columns of the sheet
+func (sheet *Sheet) HideColumns(start, end int) (err error) {
+	err = sheet.HideColumnsContext(context.Background(), start, end)
+	return
+}
+
+// HideColumnsContext is like HideColumns with the context of the request.
+func (sheet *Sheet) HideColumnsContext(ctx context.Context, start, end int) (err error) {
+	err = sheet.Spreadsheet.service.HideColumnsContext(ctx, sheet, start, end)
+	return
+}
+
+// DuplicateRow inserts count copies of the zero based source row below it
+func (sheet *Sheet) DuplicateRow(srcRow, count int) (err error) {
+	err = sheet.DuplicateRowContext(context.Background(), srcRow, count)
+	return
+}
+
+// DuplicateRowContext is like DuplicateRow with the context of the request.
+func (sheet *Sheet) DuplicateRowContext(ctx context.Context, srcRow, count int) (err error) {
+	err = sheet.Spreadsheet.service.DuplicateRowContext(ctx, sheet, srcRow, count)
 	return
 }
 
 // DeleteRows deletes rows from the sheet
 func (sheet *Sheet) DeleteRows(start, end int) (err error) {
-	err = sheet.Spreadsheet.service.DeleteRows(sheet, start, end)
+	err = sheet.DeleteRowsContext(context.Background(), start, end)
+	return
+}
+
+// DeleteRowsContext is like DeleteRows with the context of the request.
+func (sheet *Sheet) DeleteRowsContext(ctx context.Context, start, end int) (err error) {
+	err = sheet.Spreadsheet.service.DeleteRowsContext(ctx, sheet, start, end)
 	return
 }
 
 // DeleteColumns deletes columns from the sheet
 func (sheet *Sheet) DeleteColumns(start, end int) (err error) {
-	err = sheet.Spreadsheet.service.DeleteColumns(sheet, start, end)
+	err = sheet.DeleteColumnsContext(context.Background(), start, end)
+	return
+}
+
+// DeleteColumnsContext is like DeleteColumns with the context of the request.
+func (sheet *Sheet) DeleteColumnsContext(ctx context.Context, start, end int) (err error) {
+	err = sheet.Spreadsheet.service.DeleteColumnsContext(ctx, sheet, start, end)
 	return
 }
 
 // Synchronize reflects the changes of the sheet.
-func (sheet *Sheet) Synchronize() (err error) {
-	err = sheet.Spreadsheet.service.SyncSheet(sheet)
+func (sheet *Sheet) Synchronize(opts ...CallOption) (err error) {
+	err = sheet.SynchronizeContext(context.Background(), opts...)
+	return
+}
+
+// SynchronizeContext is like Synchronize with the context of the request.
+func (sheet *Sheet) SynchronizeContext(ctx context.Context, opts ...CallOption) (err error) {
+	_, err = sheet.Spreadsheet.service.SyncSheetContext(ctx, sheet, opts...)
 	return
 }
 
+// resizeDimension reflects rows or columns [start, end) inserted into or
+// deleted from the sheet on the local grid size, cells and pending changes.
+func (sheet *Sheet) resizeDimension(dimension Dimension, start, end int, insert bool) {
+	sheet.mu.Lock()
+	defer sheet.mu.Unlock()
+	length := uint(end - start)
+	props := &sheet.Properties.GridProperties
+	count, newMax := &props.RowCount, &sheet.newMaxRow
+	if dimension == DimensionColumns {
+		count, newMax = &props.ColumnCount, &sheet.newMaxColumn
+	}
+	if insert {
+		*count += length
+		*newMax += length
+	} else {
+		*count = shrink(*count, uint(start), length)
+		*newMax = shrink(*newMax, uint(start), length)
+	}
+
+	// move returns the new index of a cell and whether it still exists.
+	move := func(i uint) (uint, bool) {
+		switch {
+		case i < uint(start):
+			return i, true
+		case insert:
+			return i + length, true
+		case i < uint(end):
+			return 0, false
+		default:
+			return i - length, true
+		}
+	}
+	moveCell := func(cell *Cell) bool {
+		var ok bool
+		if dimension == DimensionColumns {
+			cell.Column, ok = move(cell.Column)
+		} else {
+			cell.Row, ok = move(cell.Row)
+		}
+		return ok
+	}
+
+	modifiedCells := make([]*Cell, 0, len(sheet.modifiedCells))
+	for _, cell := range sheet.modifiedCells {
+		if moveCell(cell) {
+			modifiedCells = append(modifiedCells, cell)
+		}
+	}
+	sheet.modifiedCells = modifiedCells
+
+	if sheet.sparse {
+		var cells []Cell
+		for _, row := range sheet.Rows {
+			for _, cell := range row {
+				if !cell.isEmpty() && moveCell(&cell) {
+					cells = append(cells, cell)
+				}
+			}
+		}
+		sheet.Rows, sheet.Columns = newSparseCells(cells)
+		return
+	}
+	if len(sheet.Rows) == 0 || len(sheet.Columns) == 0 {
+		return
+	}
+	maxRow, maxColumn := uint(len(sheet.Rows)-1), uint(len(sheet.Columns)-1)
+	if dimension == DimensionColumns {
+		maxColumn = resizeMax(maxColumn, uint(start), length, insert)
+	} else {
+		maxRow = resizeMax(maxRow, uint(start), length, insert)
+	}
+	rows, columns := newCells(maxRow, maxColumn)
+	for _, row := range sheet.Rows {
+		for _, cell := range row {
+			if !moveCell(&cell) {
+				continue
+			}
+			rows[cell.Row][cell.Column] = cell
+			columns[cell.Column][cell.Row] = cell
+		}
+	}
+	sheet.Rows, sheet.Columns = rows, columns
+}
+
+// shrink returns the size left after deleting length indexes from start.
+func shrink(size, start, length uint) uint {
+	if start >= size {
+		return size
+	}
+	if start+length >= size {
+		return start
+	}
+	return size - length
+}
+
+// resizeMax returns the max index of a cached dimension after inserting or
+// deleting length indexes from start. The cache always keeps one index.
+func resizeMax(max, start, length uint, insert bool) uint {
+	if insert {
+		if start > max {
+			return max
+		}
+		return max + length
+	}
+	size := shrink(max+1, start, length)
+	if size == 0 {
+		return 0
+	}
+	return size - 1
+}
+
+func validateDimensionRange(start, end int) error {
+	if start < 0 {
+		return errors.New("start must not be negative")
+	}
+	if end <= start {
+		return errors.New("end must be greater than start")
+	}
+	return nil
+}
+
 func newCells(maxRow, maxColumn uint) (rows, columns [][]Cell) {
 	rows = make([][]Cell, maxRow+1)
 	for i := uint(0); i < maxRow+1; i++ {
@@ -153,3 +632,87 @@ func newCells(maxRow, maxColumn uint) (rows, columns [][]Cell) {
 	}
 	return
 }
+
+// newSparseCells returns the rows and the columns holding the cells,
+// each of them trimmed after its last cell.
+func newSparseCells(cells []Cell) (rows, columns [][]Cell) {
+	var rowLengths, columnLengths []uint
+	for _, cell := range cells {
+		rowLengths = maxLength(rowLengths, cell.Row, cell.Column+1)
+		columnLengths = maxLength(columnLengths, cell.Column, cell.Row+1)
+	}
+	rows = make([][]Cell, len(rowLengths))
+	for i, n := range rowLengths {
+		rows[i] = make([]Cell, n)
+		for j := range rows[i] {
+			rows[i][j] = Cell{Row: uint(i), Column: uint(j)}
+		}
+	}
+	columns = make([][]Cell, len(columnLengths))
+	for i, n := range columnLengths {
+		columns[i] = make([]Cell, n)
+		for j := range columns[i] {
+			columns[i][j] = Cell{Row: uint(j), Column: uint(i)}
+		}
+	}
+	for _, cell := range cells {
+		rows[cell.Row][cell.Column] = cell
+		columns[cell.Column][cell.Row] = cell
+	}
+	return
+}
+
+// maxLength raises the length at the index to n.
+func maxLength(lengths []uint, i, n uint) []uint {
+	for uint(len(lengths)) <= i {
+		lengths = append(lengths, 0)
+	}
+	if lengths[i] < n {
+		lengths[i] = n
+	}
+	return lengths
+}
+
+// growCells grows the sparse rows and columns with empty cells to contain the cell at the row and the column.
+func growCells(rows, columns [][]Cell, row, column uint) ([][]Cell, [][]Cell) {
+	for uint(len(rows)) <= row {
+		rows = append(rows, nil)
+	}
+	for c := uint(len(rows[row])); c <= column; c++ {
+		rows[row] = append(rows[row], Cell{Row: row, Column: c})
+	}
+	for uint(len(columns)) <= column {
+		columns = append(columns, nil)
+	}
+	for r := uint(len(columns[column])); r <= row; r++ {
+		columns[column] = append(columns[column], Cell{Row: r, Column: column})
+	}
+	return rows, columns
+}
+
+// LoadData fetches the cells of the sheet, e.g. of a sheet fetched WithLazyLoading.
+// The properties of the sheet are refreshed and changes not synchronized yet are discarded.
+func (sheet *Sheet) LoadData(ctx context.Context, opts ...CallOption) (err error) {
+	if err = ctx.Err(); err != nil {
+		return
+	}
+	spreadsheet := sheet.Spreadsheet
+	fetched, err := spreadsheet.service.fetchSpreadsheet(ctx, spreadsheet.ID, []string{quoteSheetTitle(sheet.Properties.Title)}, sheet.callOptions(opts))
+	if err != nil {
+		return
+	}
+	loaded, err := fetched.SheetByID(sheet.Properties.ID)
+	if err != nil {
+		return
+	}
+	sheet.mu.Lock()
+	defer sheet.mu.Unlock()
+	sheet.Properties = loaded.Properties
+	sheet.Data = loaded.Data
+	sheet.TmpData = loaded.TmpData
+	sheet.Rows, sheet.Columns = loaded.Rows, loaded.Columns
+	sheet.modifiedCells = loaded.modifiedCells
+	sheet.newMaxRow, sheet.newMaxColumn = loaded.newMaxRow, loaded.newMaxColumn
+	sheet.sparse = loaded.sparse
+	return
+}
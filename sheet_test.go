@@ -1,6 +1,12 @@
 package spreadsheet
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,3 +27,635 @@ func TestNewCells(t *testing.T) {
 	assert.Equal(uint(0), columns[2][0].Row)
 	assert.Equal(uint(2), columns[2][2].Column)
 }
+
+func TestResizeDimension(t *testing.T) {
+	assert := assert.New(t)
+	sheet := &Sheet{}
+	sheet.Properties.GridProperties.RowCount = 5
+	sheet.Properties.GridProperties.ColumnCount = 4
+	sheet.newMaxRow = 5
+	sheet.newMaxColumn = 4
+	sheet.Update(1, 1, "B2")
+	sheet.Update(3, 2, "C4")
+
+	sheet.resizeDimension("COLUMNS", 0, 1, false)
+	assert.Equal(uint(5), sheet.Properties.GridProperties.RowCount)
+	assert.Equal(uint(3), sheet.Properties.GridProperties.ColumnCount)
+	assert.Equal(uint(5), sheet.newMaxRow)
+	assert.Equal(uint(3), sheet.newMaxColumn)
+	assert.Equal("B2", sheet.Rows[1][0].Value)
+	assert.Equal("C4", sheet.Columns[1][3].Value)
+	assert.Equal(uint(1), sheet.Columns[1][3].Column)
+
+	sheet.resizeDimension("ROWS", 0, 2, true)
+	assert.Equal(uint(7), sheet.Properties.GridProperties.RowCount)
+	assert.Equal("B2", sheet.Rows[3][0].Value)
+	assert.Equal("C4", sheet.Rows[5][1].Value)
+	assert.Equal(uint(5), sheet.Rows[5][1].Row)
+
+	sheet.resizeDimension("ROWS", 3, 4, false)
+	assert.Equal(uint(6), sheet.Properties.GridProperties.RowCount)
+	assert.Equal("C4", sheet.Rows[4][1].Value)
+	assert.Len(sheet.modifiedCells, 1)
+	assert.Equal("C4", sheet.modifiedCells[0].Value)
+	assert.Equal(uint(4), sheet.modifiedCells[0].Row)
+	assert.Equal(uint(1), sheet.modifiedCells[0].Column)
+}
+
+func TestResizeDimensionOutOfGrid(t *testing.T) {
+	assert := assert.New(t)
+	sheet := &Sheet{}
+	sheet.Properties.GridProperties.ColumnCount = 3
+	sheet.newMaxColumn = 3
+
+	sheet.resizeDimension("COLUMNS", 1, 10, false)
+	assert.Equal(uint(1), sheet.Properties.GridProperties.ColumnCount)
+	assert.Equal(uint(1), sheet.newMaxColumn)
+}
+
+func TestValidateDimensionRange(t *testing.T) {
+	assert := assert.New(t)
+	assert.NoError(validateDimensionRange(0, 1))
+	assert.Error(validateDimensionRange(-1, 1))
+	assert.Error(validateDimensionRange(2, 2))
+	assert.Error(validateDimensionRange(3, 1))
+}
+
+func TestSheetValue(t *testing.T) {
+	assert := assert.New(t)
+	sheet := &Sheet{}
+	err := json.Unmarshal([]byte(`{
+		"properties": {"gridProperties": {"rowCount": 3, "columnCount": 2}},
+		"data": [{"rowData": [{"values": [{"formattedValue": "a"}]}]}]
+	}`), sheet)
+	assert.NoError(err)
+
+	value, err := sheet.Value(0, 0)
+	assert.NoError(err)
+	assert.Equal("a", value)
+	value, err = sheet.Value(2, 1)
+	assert.NoError(err)
+	assert.Equal("", value)
+	cell, err := sheet.Cell(2, 1)
+	assert.NoError(err)
+	assert.Equal("B3", cell.Pos())
+
+	_, err = sheet.Value(3, 0)
+	assert.Equal(&OutOfRangeError{Row: 3, Column: 0, RowCount: 3, ColumnCount: 2}, err)
+	_, err = sheet.Value(0, -1)
+	assert.IsType(&OutOfRangeError{}, err)
+	assert.EqualError(err, "cell (0, -1) is out of the 3x2 grid")
+}
+
+func TestSheetCellsSpanGrid(t *testing.T) {
+	assert := assert.New(t)
+	sheet := &Sheet{}
+	err := json.Unmarshal([]byte(`{
+		"properties": {"gridProperties": {"rowCount": 3, "columnCount": 4}},
+		"data": [{"rowData": [{"values": [{"formattedValue": "a"}, {"formattedValue": "b"}]}]}]
+	}`), sheet)
+	assert.NoError(err)
+	assert.Len(sheet.Rows, 3)
+	assert.Len(sheet.Columns, 4)
+	for _, row := range sheet.Rows {
+		assert.Len(row, 4)
+	}
+	assert.Equal("b", sheet.Rows[0][1].Value)
+	assert.Equal(Cell{Row: 2, Column: 3}, sheet.Rows[2][3])
+	assert.Equal(Cell{Row: 2, Column: 3}, sheet.Columns[3][2])
+
+	// without grid data, e.g. fetched WithLazyLoading, the cells aren't allocated
+	sheet = &Sheet{}
+	assert.NoError(json.Unmarshal([]byte(`{"properties": {"gridProperties": {"rowCount": 1000, "columnCount": 26}}}`), sheet))
+	assert.Len(sheet.Rows, 1)
+}
+
+func TestEmptySheet(t *testing.T) {
+	assert := assert.New(t)
+	sheet := &Sheet{}
+	assert.NoError(json.Unmarshal([]byte(`{"properties": {"title": "empty"}}`), sheet))
+	assert.NotNil(sheet.Rows)
+	assert.NotNil(sheet.Columns)
+	_, err := sheet.Value(0, 0)
+	assert.IsType(&OutOfRangeError{}, err)
+
+	sheet = &Sheet{}
+	_, err = sheet.Cell(0, 0)
+	assert.IsType(&OutOfRangeError{}, err)
+	sheet.Update(1, 1, "x")
+	value, err := sheet.Value(1, 1)
+	assert.NoError(err)
+	assert.Equal("x", value)
+}
+
+func TestUpdateBoundsPolicy(t *testing.T) {
+	assert := assert.New(t)
+	sheet := &Sheet{}
+	sheet.Properties.GridProperties.RowCount = 2
+	sheet.Properties.GridProperties.ColumnCount = 2
+	sheet.newMaxRow = 2
+	sheet.newMaxColumn = 2
+
+	assert.NoError(sheet.Update(3, 4, "expanded"))
+	assert.Equal(uint(4), sheet.newMaxRow)
+	assert.Equal(uint(5), sheet.newMaxColumn)
+	assert.IsType(&OutOfRangeError{}, sheet.Update(-1, 0, "negative"))
+
+	sheet.SetBoundsPolicy(BoundsStrict)
+	assert.NoError(sheet.Update(3, 4, "inside"))
+	err := sheet.Update(4, 0, "outside")
+	assert.Equal(&OutOfRangeError{Row: 4, Column: 0, RowCount: 4, ColumnCount: 5}, err)
+	assert.Len(sheet.modifiedCells, 1)
+	assert.Equal("inside", sheet.modifiedCells[0].Value)
+}
+
+func TestRenderValues(t *testing.T) {
+	assert := assert.New(t)
+	sheet := &Sheet{}
+	err := json.Unmarshal([]byte(`{
+		"data": [{"rowData": [{"values": [
+			{"formattedValue": "1,234.50", "userEnteredValue": {"numberValue": 1234.5}, "effectiveValue": {"numberValue": 1234.5}},
+			{"formattedValue": "3", "userEnteredValue": {"formulaValue": "=1+2"}, "effectiveValue": {"numberValue": 3}}
+		]}]}]
+	}`), sheet)
+	assert.NoError(err)
+	assert.Equal("1,234.50", sheet.Rows[0][0].Value)
+
+	sheet.renderValues(ValueRenderUnformatted)
+	assert.Equal("1234.5", sheet.Rows[0][0].Value)
+	assert.Equal("3", sheet.Columns[1][0].Value)
+
+	sheet.renderValues(ValueRenderFormula)
+	assert.Equal("1234.5", sheet.Rows[0][0].Value)
+	assert.Equal("=1+2", sheet.Rows[0][1].Value)
+}
+
+func TestCellRuns(t *testing.T) {
+	assert := assert.New(t)
+	cells := []*Cell{
+		{Row: 1, Column: 1, Value: "b2"},
+		{Row: 0, Column: 1, Value: "b1"},
+		{Row: 0, Column: 0, Value: "a1"},
+		{Row: 0, Column: 3, Value: "d1"},
+		{Row: 1, Column: 0, Value: "a2"},
+	}
+	values := func(runs [][]*Cell) (values [][]string) {
+		for _, run := range runs {
+			var vs []string
+			for _, cell := range run {
+				vs = append(vs, cell.Value)
+			}
+			values = append(values, vs)
+		}
+		return
+	}
+	assert.Equal([][]string{{"a1", "b1"}, {"d1"}, {"a2", "b2"}}, values(cellRuns(cells, DimensionRows)))
+	assert.Equal([][]string{{"a1", "a2"}, {"b1", "b2"}, {"d1"}}, values(cellRuns(cells, DimensionColumns)))
+	assert.Empty(cellRuns(nil, DimensionRows))
+}
+
+func TestConcurrentUpdateAndSync(t *testing.T) {
+	assert := assert.New(t)
+	var mu sync.Mutex
+	sent := map[string]bool{}
+	var gridSizes []GridProperties
+	var onRequest func() int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Data []struct {
+				Range  string     `json:"range"`
+				Values [][]string `json:"values"`
+			} `json:"data"`
+			Requests []struct {
+				UpdateSheetProperties struct {
+					Properties SheetProperties `json:"properties"`
+				} `json:"updateSheetProperties"`
+			} `json:"requests"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		for _, request := range body.Requests {
+			gridSizes = append(gridSizes, request.UpdateSheetProperties.Properties.GridProperties)
+		}
+		mu.Unlock()
+		status := http.StatusOK
+		if onRequest != nil {
+			status = onRequest()
+		}
+		if status == http.StatusOK {
+			mu.Lock()
+			for _, data := range body.Data {
+				for _, values := range data.Values {
+					for _, v := range values {
+						assert.False(sent[v], v)
+						sent[v] = true
+					}
+				}
+			}
+			mu.Unlock()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	spreadsheet := &Spreadsheet{ID: "abc", service: s, Sheets: []Sheet{{Properties: SheetProperties{
+		Title:          "s",
+		GridProperties: GridProperties{RowCount: 10, ColumnCount: 10},
+	}}}}
+	sheet := &spreadsheet.Sheets[0]
+	sheet.Spreadsheet = spreadsheet
+
+	// the workers write past the 10x10 grid, so that concurrent synchronizations expand it
+	var wg sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		wg.Add(1)
+		go func(row int) {
+			defer wg.Done()
+			for column := 0; column < 12; column++ {
+				assert.NoError(sheet.Update(row, column, fmt.Sprintf("%d-%d", row, column)))
+				if column == 5 || column == 11 {
+					_, err := s.SyncSheet(sheet)
+					assert.NoError(err)
+				}
+			}
+		}(worker * 3)
+	}
+	wg.Wait()
+	_, err := s.SyncSheet(sheet)
+	assert.NoError(err)
+	assert.Len(sent, 96)
+	assert.Equal(uint(22), sheet.Properties.GridProperties.RowCount)
+	assert.Equal(uint(12), sheet.Properties.GridProperties.ColumnCount)
+	if assert.NotEmpty(gridSizes) {
+		for i := 1; i < len(gridSizes); i++ {
+			assert.True(gridSizes[i].RowCount >= gridSizes[i-1].RowCount, "shrunk rows: %v", gridSizes)
+			assert.True(gridSizes[i].ColumnCount >= gridSizes[i-1].ColumnCount, "shrunk columns: %v", gridSizes)
+		}
+		assert.Equal(GridProperties{RowCount: 22, ColumnCount: 12}, gridSizes[len(gridSizes)-1])
+	}
+	assert.Empty(sheet.modifiedCells)
+
+	sheet.Update(0, 0, "old")
+	sheet.Update(0, 1, "kept")
+	onRequest = func() int {
+		sheet.Update(0, 0, "new")
+		sheet.Update(1, 0, "added")
+		return http.StatusBadRequest
+	}
+	_, err = s.SyncSheet(sheet)
+	assert.Error(err)
+	var values []string
+	for _, cell := range sheet.modifiedCells {
+		values = append(values, cell.Value)
+	}
+	assert.Equal([]string{"kept", "new", "added"}, values)
+}
+
+func TestDuplicateRowCopiesCells(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	data := `{"properties":{"sheetId":7,"title":"s","gridProperties":{"rowCount":3,"columnCount":3}},"data":[{"rowData":[
+		{"values":[
+			{"formattedValue":"1.50","userEnteredValue":{"numberValue":1.5},"effectiveValue":{"numberValue":1.5}},
+			{"hyperlink":"https://example.com"},
+			{"userEnteredValue":{"formulaValue":"=A1"}}
+		]}
+	]}]}`
+
+	for _, sparse := range []bool{false, true} {
+		spreadsheet := &Spreadsheet{ID: "abc", service: s, Sheets: []Sheet{{sparse: sparse}}}
+		sheet := &spreadsheet.Sheets[0]
+		assert.NoError(json.Unmarshal([]byte(data), sheet))
+		sheet.Spreadsheet = spreadsheet
+		assert.NoError(sheet.DuplicateRow(0, 2))
+		for _, row := range []int{1, 2} {
+			cell := sheet.Rows[row][0]
+			assert.Equal(uint(row), cell.Row)
+			assert.Equal("1.50", cell.Value)
+			assert.Equal("1.50", cell.FormattedValue)
+			if assert.NotNil(cell.EffectiveValue) {
+				assert.Equal(1.5, cell.EffectiveValue.NumberValue)
+			}
+			assert.Equal("https://example.com", sheet.Rows[row][1].Hyperlink)
+			formula, ok := sheet.Rows[row][2].FormulaValue()
+			assert.True(ok)
+			assert.Equal("=A1", formula)
+			assert.Equal(sheet.Rows[row][2], sheet.Columns[2][row])
+		}
+	}
+}
+
+func TestSparseCellsKeepFetchedFields(t *testing.T) {
+	assert := assert.New(t)
+	data := `{"data":[{"rowData":[
+		{"values":[{},{"hyperlink":"https://example.com"}]},
+		{"values":[{},{},{"effectiveValue":{"numberValue":0}}]},
+		{"values":[{"userEnteredValue":{"stringValue":""}}]},
+		{"values":[{}]}
+	]}]}`
+	var sheet Sheet
+	sheet.sparse = true
+	assert.NoError(json.Unmarshal([]byte(data), &sheet))
+	if assert.Len(sheet.Rows, 3) {
+		assert.Equal("https://example.com", sheet.Rows[0][1].Hyperlink)
+		assert.True(sheet.Rows[1][2].EffectiveValue.IsNumber())
+		assert.NotNil(sheet.Rows[2][0].UserEnteredValue)
+	}
+}
+
+func TestSparseCells(t *testing.T) {
+	assert := assert.New(t)
+	data := `{"properties":{"gridProperties":{"rowCount":100,"columnCount":26}},"data":[{"rowData":[
+		{"values":[{"formattedValue":"a"},{},{"formattedValue":"c"},{}]},
+		{"values":[{},{}]},
+		{"values":[{},{"formattedValue":"b"}]}
+	]}]}`
+	var sheet Sheet
+	sheet.sparse = true
+	assert.NoError(json.Unmarshal([]byte(data), &sheet))
+	if assert.Len(sheet.Rows, 3) {
+		assert.Len(sheet.Rows[0], 3)
+		assert.Empty(sheet.Rows[1])
+		assert.Len(sheet.Rows[2], 2)
+	}
+	if assert.Len(sheet.Columns, 3) {
+		assert.Len(sheet.Columns[0], 1)
+		assert.Len(sheet.Columns[1], 3)
+		assert.Len(sheet.Columns[2], 1)
+	}
+	assert.Equal(Cell{Row: 0, Column: 1}, sheet.Rows[0][1])
+	value, err := sheet.Value(1, 3)
+	assert.NoError(err)
+	assert.Equal("", value)
+	value, err = sheet.Value(2, 1)
+	assert.NoError(err)
+	assert.Equal("b", value)
+
+	assert.NoError(sheet.Update(5, 4, "x"))
+	assert.Len(sheet.Rows, 6)
+	assert.Len(sheet.Rows[5], 5)
+	assert.Len(sheet.Columns[4], 6)
+	assert.Len(sheet.Rows[3], 0)
+
+	sheet.resizeDimension(DimensionRows, 0, 1, true)
+	value, _ = sheet.Value(3, 1)
+	assert.Equal("b", value)
+	value, _ = sheet.Value(6, 4)
+	assert.Equal("x", value)
+	assert.Empty(sheet.Rows[0])
+
+	dense := Sheet{}
+	assert.NoError(json.Unmarshal([]byte(data), &dense))
+	dense.Update(5, 4, "x")
+	dense.resizeDimension(DimensionRows, 0, 1, true)
+	assert.Equal(dense.Hash(), sheet.Hash())
+}
+
+func TestCellBlocks(t *testing.T) {
+	assert := assert.New(t)
+	var cells []*Cell
+	for _, pos := range [][2]uint{{0, 0}, {0, 1}, {1, 0}, {1, 1}, {2, 0}, {2, 1}, {2, 2}, {3, 0}, {3, 1}, {5, 0}, {5, 1}} {
+		cells = append(cells, &Cell{Row: pos[0], Column: pos[1], Value: cellRange(pos[0], pos[1], 1, 1)})
+	}
+	ranges := func(blocks [][][]*Cell) (ranges []string) {
+		for _, block := range blocks {
+			first, lastRun := block[0][0], block[len(block)-1]
+			last := lastRun[len(lastRun)-1]
+			ranges = append(ranges, first.Value+":"+last.Value)
+		}
+		return
+	}
+	assert.Equal([]string{"A1:B2", "A3:C3", "A4:B4", "A6:B6"}, ranges(cellBlocks(cellRuns(cells, DimensionRows), DimensionRows)))
+	assert.Equal([]string{"A1:B4", "A6:B6", "C3:C3"}, ranges(cellBlocks(cellRuns(cells, DimensionColumns), DimensionColumns)))
+	assert.Empty(cellBlocks(nil, DimensionRows))
+}
+
+func TestSyncBatchLimit(t *testing.T) {
+	assert := assert.New(t)
+	var requests [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Data []struct {
+				Range string `json:"range"`
+			} `json:"data"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		var ranges []string
+		for _, data := range body.Data {
+			ranges = append(ranges, data.Range)
+		}
+		requests = append(requests, ranges)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	spreadsheet := &Spreadsheet{ID: "abc", service: s, Sheets: []Sheet{{Properties: SheetProperties{
+		Title:          "s",
+		GridProperties: GridProperties{RowCount: 10, ColumnCount: 10},
+	}}}}
+	sheet := &spreadsheet.Sheets[0]
+	sheet.Spreadsheet = spreadsheet
+	update := func() {
+		for row := 0; row < 3; row++ {
+			for column := 0; column < 10; column++ {
+				sheet.Update(row, column, "x")
+			}
+		}
+		sheet.Update(5, 0, "x")
+	}
+
+	update()
+	_, err := s.SyncSheet(sheet)
+	assert.NoError(err)
+	assert.Equal([][]string{{"'s'!A1:J3", "'s'!A6"}}, requests)
+
+	requests = nil
+	update()
+	_, err = s.SyncSheet(sheet, WithSyncBatchLimit(20, 0))
+	assert.NoError(err)
+	assert.Equal([][]string{{"'s'!A1:J2"}, {"'s'!A3:J3", "'s'!A6"}}, requests)
+
+	requests = nil
+	update()
+	_, err = s.SyncSheet(sheet, WithSyncBatchLimit(4, 0))
+	assert.NoError(err)
+	assert.Len(requests, 9)
+	assert.Equal([]string{"'s'!A1:D1"}, requests[0])
+	assert.Equal([]string{"'s'!I3:J3", "'s'!A6"}, requests[8])
+
+	requests = nil
+	update()
+	_, err = s.SyncSheet(sheet, WithSyncBatchLimit(0, 1))
+	assert.NoError(err)
+	assert.Equal([][]string{{"'s'!A1:J3"}, {"'s'!A6"}}, requests)
+	assert.Empty(sheet.modifiedCells)
+}
+
+func TestSyncFormulasBatchLimit(t *testing.T) {
+	assert := assert.New(t)
+	var requests []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/spreadsheets/abc:batchUpdate", r.URL.Path)
+		var body struct {
+			Requests []interface{} `json:"requests"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		requests = append(requests, len(body.Requests))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	spreadsheet := &Spreadsheet{ID: "abc", service: s, Sheets: []Sheet{{Properties: SheetProperties{
+		Title:          "s",
+		GridProperties: GridProperties{RowCount: 10, ColumnCount: 10},
+	}}}}
+	sheet := &spreadsheet.Sheets[0]
+	sheet.Spreadsheet = spreadsheet
+	update := func() {
+		assert.NoError(sheet.UpdateFormula(0, 0, "=LEN(\"a long text\")"))
+		assert.NoError(sheet.UpdateFormula(5, 0, "=LEN(\"another long text\")"))
+	}
+
+	update()
+	_, err := s.SyncSheet(sheet)
+	assert.NoError(err)
+	assert.Equal([]int{2}, requests)
+
+	requests = nil
+	update()
+	_, err = s.SyncSheet(sheet, WithSyncBatchLimit(0, 1))
+	assert.NoError(err)
+	assert.Equal([]int{1, 1}, requests)
+}
+
+func TestTypedCellValues(t *testing.T) {
+	assert := assert.New(t)
+	var sheet Sheet
+	err := json.Unmarshal([]byte(`{"properties":{"title":"s","gridProperties":{"rowCount":2,"columnCount":4}},"data":[{"rowData":[{"values":[
+		{"userEnteredValue":{"numberValue":1.5},"formattedValue":"1.50"},
+		{"userEnteredValue":{"boolValue":true},"formattedValue":"TRUE"},
+		{"userEnteredValue":{"formulaValue":"=A1*2"},"formattedValue":"3.00"},
+		{"userEnteredValue":{"stringValue":"007"},"formattedValue":"007"}
+	]}]}]}`), &sheet)
+	assert.NoError(err)
+	number, ok := sheet.Rows[0][0].NumberValue()
+	assert.True(ok)
+	assert.Equal(1.5, number)
+	_, ok = sheet.Rows[0][0].StringValue()
+	assert.False(ok)
+	b, ok := sheet.Rows[0][1].BoolValue()
+	assert.True(ok)
+	assert.True(b)
+	formula, ok := sheet.Rows[0][2].FormulaValue()
+	assert.True(ok)
+	assert.Equal("=A1*2", formula)
+	assert.Equal("3.00", sheet.Rows[0][2].Value)
+	s, ok := sheet.Rows[0][3].StringValue()
+	assert.True(ok)
+	assert.Equal("007", s)
+
+	var values [][]interface{}
+	var requests []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Data []struct {
+				Values [][]interface{} `json:"values"`
+			} `json:"data"`
+			Requests []map[string]interface{} `json:"requests"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if strings.HasSuffix(r.URL.Path, "/values:batchUpdate") {
+			values = body.Data[0].Values
+		}
+		requests = append(requests, body.Requests...)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	service := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	sheet.Spreadsheet = &Spreadsheet{ID: "abc", service: service}
+	assert.NoError(sheet.UpdateNumber(1, 0, 2))
+	assert.NoError(sheet.UpdateBool(1, 1, false))
+	assert.NoError(sheet.UpdateFormula(1, 2, "=A2*2"))
+	assert.NoError(sheet.Update(1, 3, "007"))
+	assert.Error(sheet.UpdateFormula(1, 2, "A2*2"))
+	assert.Equal("FALSE", sheet.Rows[1][1].Value)
+	result, err := service.SyncSheet(&sheet, WithValueInputOption(ValueInputRaw))
+	assert.NoError(err)
+	assert.Equal(3, result.RangesSent)
+	assert.Equal([][]interface{}{{2.0, false}}, values)
+	assert.Equal([]map[string]interface{}{{"updateCells": map[string]interface{}{
+		"start":  map[string]interface{}{"sheetId": 0.0, "rowIndex": 1.0, "columnIndex": 2.0},
+		"rows":   []interface{}{map[string]interface{}{"values": []interface{}{map[string]interface{}{"userEnteredValue": map[string]interface{}{"formulaValue": "=A2*2"}}}}},
+		"fields": "userEnteredValue",
+	}}}, requests)
+
+	values, requests = nil, nil
+	assert.Error(sheet.UpdateFormulas(0, 0, [][]string{{"=1", "2"}}))
+	assert.Empty(sheet.modifiedCells)
+	assert.NoError(sheet.UpdateFormulas(0, 0, [][]string{{"=1", "", "=3"}, {"=4"}}))
+	_, err = service.SyncSheet(&sheet)
+	assert.NoError(err)
+	assert.Nil(values)
+	assert.Len(requests, 2)
+}
+
+func TestFormattedAndEffectiveValues(t *testing.T) {
+	assert := assert.New(t)
+	var fields string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = r.URL.Query().Get("fields")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"spreadsheetId":"abc","sheets":[{"properties":{"title":"s"},"data":[{"rowData":[{"values":[
+			{"formattedValue":"$3.00","userEnteredValue":{"formulaValue":"=1+2"},"effectiveValue":{"numberValue":3}}
+		]}]}]}]}`))
+	}))
+	defer server.Close()
+
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	spreadsheet, err := s.FetchSpreadsheet("abc")
+	assert.NoError(err)
+	assert.NotContains(fields, "effectiveValue")
+	spreadsheet, err = s.FetchSpreadsheet("abc", WithEffectiveValues(), WithValueRenderOption(ValueRenderFormula))
+	assert.NoError(err)
+	assert.Contains(fields, "effectiveValue")
+	cell := spreadsheet.Sheets[0].Rows[0][0]
+	assert.Equal("=1+2", cell.Value)
+	assert.Equal("$3.00", cell.FormattedValue)
+	assert.Equal("3", cell.EffectiveValue.String())
+	formula, _ := cell.FormulaValue()
+	assert.Equal("=1+2", formula)
+}
+
+func TestUpdateLink(t *testing.T) {
+	assert := assert.New(t)
+	var sheet Sheet
+	err := json.Unmarshal([]byte(`{"properties":{"title":"s","gridProperties":{"rowCount":2,"columnCount":2}},"data":[{"rowData":[{"values":[
+		{"userEnteredValue":{"formulaValue":"=HYPERLINK(\"https://example.com\")"},"formattedValue":"https://example.com","hyperlink":"https://example.com"}
+	]}]}]}`), &sheet)
+	assert.NoError(err)
+	assert.Equal("https://example.com", sheet.Rows[0][0].Hyperlink)
+
+	assert.NoError(sheet.UpdateLink(1, 0, "https://example.com/a", `the "a" doc`))
+	cell := sheet.Rows[1][0]
+	assert.Equal(`the "a" doc`, cell.Value)
+	assert.Equal("https://example.com/a", cell.Hyperlink)
+	formula, _ := cell.FormulaValue()
+	assert.Equal(`=HYPERLINK("https://example.com/a","the ""a"" doc")`, formula)
+	assert.NoError(sheet.UpdateLink(1, 1, "https://example.com/b", ""))
+	formula, _ = sheet.modifiedCells[1].FormulaValue()
+	assert.Equal(`=HYPERLINK("https://example.com/b")`, formula)
+	assert.Equal("https://example.com/b", sheet.modifiedCells[1].Value)
+}
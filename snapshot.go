@@ -0,0 +1,74 @@
+package spreadsheet
+
+// Snapshot is a copy of the cell values of a sheet with a hash per row,
+// for detecting which cells changed between two points in time.
+type Snapshot struct {
+	rows   [][]string
+	hashes []rowHash
+}
+
+// CellChange is a cell whose value differs between two snapshots.
+type CellChange struct {
+	Row    uint
+	Column uint
+	Old    string
+	New    string
+}
+
+// Snapshot takes a snapshot of the current cell values of the sheet.
+func (sheet *Sheet) Snapshot() Snapshot {
+	sheet.mu.Lock()
+	defer sheet.mu.Unlock()
+	snapshot := Snapshot{
+		rows:   make([][]string, len(sheet.Rows)),
+		hashes: rowHashes(sheet.Rows),
+	}
+	for i, row := range sheet.Rows {
+		values := make([]string, len(row))
+		for j, cell := range row {
+			values[j] = cell.Value
+		}
+		snapshot.rows[i] = values
+	}
+	return snapshot
+}
+
+// Diff returns the cells changed from the snapshot to the newer snapshot, ordered by row and column.
+// Only the cells of rows with different hashes are compared, so mostly unchanged sheets are diffed quickly.
+func (s Snapshot) Diff(newer Snapshot) (changes []CellChange) {
+	n := len(s.rows)
+	if len(newer.rows) > n {
+		n = len(newer.rows)
+	}
+	for i := 0; i < n; i++ {
+		if s.rowHash(i) == newer.rowHash(i) {
+			continue
+		}
+		old, cur := s.row(i), newer.row(i)
+		columns := len(old)
+		if len(cur) > columns {
+			columns = len(cur)
+		}
+		for j := 0; j < columns; j++ {
+			o, v := valueOrEmpty(old, j), valueOrEmpty(cur, j)
+			if o != v {
+				changes = append(changes, CellChange{Row: uint(i), Column: uint(j), Old: o, New: v})
+			}
+		}
+	}
+	return
+}
+
+func (s Snapshot) row(i int) []string {
+	if i < len(s.rows) {
+		return s.rows[i]
+	}
+	return nil
+}
+
+func (s Snapshot) rowHash(i int) rowHash {
+	if i < len(s.hashes) {
+		return s.hashes[i]
+	}
+	return emptyRowHash
+}
@@ -0,0 +1,26 @@
+package spreadsheet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotDiff(t *testing.T) {
+	assert := assert.New(t)
+	sheet := newTestSheet("one", 0, []string{"a", "b"}, []string{"c"})
+	before := sheet.Snapshot()
+	assert.Empty(before.Diff(sheet.Snapshot()))
+
+	sheet.Update(0, 1, "B")
+	sheet.Update(3, 2, "new")
+	after := sheet.Snapshot()
+	assert.Equal([]CellChange{
+		{Row: 0, Column: 1, Old: "b", New: "B"},
+		{Row: 3, Column: 2, Old: "", New: "new"},
+	}, before.Diff(after))
+	assert.Equal([]CellChange{
+		{Row: 0, Column: 1, Old: "B", New: "b"},
+		{Row: 3, Column: 2, Old: "new", New: ""},
+	}, after.Diff(before))
+}
@@ -3,16 +3,20 @@ package spreadsheet
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 )
 
 // Spreadsheet represents a spreadsheet.
 type Spreadsheet struct {
-	ID         string     `json:"spreadsheetId"`
-	Properties Properties `json:"properties"`
-	Sheets     []Sheet    `json:"sheets"`
-	// NamedRanges []*NamedRange `json:"namedRanges"`
+	ID          string       `json:"spreadsheetId"`
+	Properties  Properties   `json:"properties"`
+	Sheets      []Sheet      `json:"sheets"`
+	NamedRanges []NamedRange `json:"namedRanges"`
 
-	service *Service
+	service        *Service
+	defaultOptions []CallOption
+	stale          bool
 }
 
 // UnmarshalJSON embeds spreadsheet to sheets.
@@ -22,17 +26,105 @@ func (spreadsheet *Spreadsheet) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, a); err != nil {
 		return err
 	}
+	spreadsheet.linkSheets()
+	return nil
+}
+
+// linkSheets links the sheets to the spreadsheet. Spreadsheets returned by value are linked again by the callers,
+// so that the sheets refer to the returned copy, which has the service.
+func (spreadsheet *Spreadsheet) linkSheets() {
 	for i := range spreadsheet.Sheets {
 		spreadsheet.Sheets[i].Spreadsheet = spreadsheet
 	}
-	return nil
+}
+
+// readSpreadsheet decodes the spreadsheet as it's read, a sheet at a time,
+// so that the JSON of a single sheet is buffered rather than the one of the whole spreadsheet.
+// The cells of the sheets are kept sparse if sparse is set.
+func readSpreadsheet(r io.Reader, sparse bool) (spreadsheet Spreadsheet, err error) {
+	dec := json.NewDecoder(r)
+	if err = readDelim(dec, '{'); err != nil {
+		return
+	}
+	fields := map[string]json.RawMessage{}
+	for dec.More() {
+		var key json.Token
+		key, err = dec.Token()
+		if err != nil {
+			return
+		}
+		if key != "sheets" {
+			var value json.RawMessage
+			if err = dec.Decode(&value); err != nil {
+				return
+			}
+			fields[key.(string)] = value
+			continue
+		}
+		if err = readSheets(dec, &spreadsheet, sparse); err != nil {
+			return
+		}
+	}
+	if err = readDelim(dec, '}'); err != nil {
+		return
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return
+	}
+	type Alias Spreadsheet
+	err = json.Unmarshal(data, (*Alias)(&spreadsheet))
+	return
+}
+
+// readSheets decodes the array of the sheets one by one.
+func readSheets(dec *json.Decoder, spreadsheet *Spreadsheet, sparse bool) (err error) {
+	tok, err := dec.Token()
+	if err != nil || tok == nil {
+		return
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("invalid sheets: %v", tok)
+	}
+	for dec.More() {
+		spreadsheet.Sheets = append(spreadsheet.Sheets, Sheet{sparse: sparse})
+		if err = dec.Decode(&spreadsheet.Sheets[len(spreadsheet.Sheets)-1]); err != nil {
+			return
+		}
+	}
+	err = readDelim(dec, ']')
+	return
+}
+
+// readDelim reads the delimiter from the decoder.
+func readDelim(dec *json.Decoder, delim json.Delim) (err error) {
+	tok, err := dec.Token()
+	if err == nil && tok != delim {
+		err = fmt.Errorf("invalid spreadsheet: expected %v, got %v", delim, tok)
+	}
+	return
+}
+
+// reload replaces the properties and the sheets by the ones of the new spreadsheet.
+func (spreadsheet *Spreadsheet) reload(newSpreadsheet Spreadsheet) {
+	spreadsheet.stale = false
+	spreadsheet.Properties = newSpreadsheet.Properties
+	spreadsheet.Sheets = newSpreadsheet.Sheets
+	spreadsheet.NamedRanges = newSpreadsheet.NamedRanges
+	spreadsheet.linkSheets()
+}
+
+// NeedsReload reports whether the spreadsheet was changed with WithoutReload since it was last loaded,
+// so that its properties and sheets may be out of date until ReloadSpreadsheet.
+func (spreadsheet *Spreadsheet) NeedsReload() bool {
+	return spreadsheet.stale
 }
 
 // SheetByIndex gets a sheet by the given index.
 func (spreadsheet *Spreadsheet) SheetByIndex(i uint) (sheet *Sheet, err error) {
-	for _, s := range spreadsheet.Sheets {
-		if s.Properties.Index == i {
-			sheet = &s
+	for j := range spreadsheet.Sheets {
+		if spreadsheet.Sheets[j].Properties.Index == i {
+			sheet = &spreadsheet.Sheets[j]
 			return
 		}
 	}
@@ -42,9 +134,9 @@ func (spreadsheet *Spreadsheet) SheetByIndex(i uint) (sheet *Sheet, err error) {
 
 // SheetByID gets a sheet by the given ID.
 func (spreadsheet *Spreadsheet) SheetByID(id uint) (sheet *Sheet, err error) {
-	for _, s := range spreadsheet.Sheets {
-		if s.Properties.ID == id {
-			sheet = &s
+	for i := range spreadsheet.Sheets {
+		if spreadsheet.Sheets[i].Properties.ID == id {
+			sheet = &spreadsheet.Sheets[i]
 			return
 		}
 	}
@@ -54,9 +146,9 @@ func (spreadsheet *Spreadsheet) SheetByID(id uint) (sheet *Sheet, err error) {
 
 // SheetByTitle gets a sheet by the given title.
 func (spreadsheet *Spreadsheet) SheetByTitle(title string) (sheet *Sheet, err error) {
-	for _, s := range spreadsheet.Sheets {
-		if s.Properties.Title == title {
-			sheet = &s
+	for i := range spreadsheet.Sheets {
+		if spreadsheet.Sheets[i].Properties.Title == title {
+			sheet = &spreadsheet.Sheets[i]
 			return
 		}
 	}
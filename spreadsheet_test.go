@@ -0,0 +1,118 @@
+package spreadsheet
+
+import (
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSpreadsheetJSON = `{
+	"spreadsheetId": "abc",
+	"properties": {"title": "Book"},
+	"sheets": [
+		{"properties": {"sheetId": 1, "title": "one"}, "data": [{"rowData": [{"values": [{"formattedValue": "a"}, {"formattedValue": "b"}]}]}]},
+		{"properties": {"sheetId": 2, "title": "two", "index": 1}}
+	],
+	"namedRanges": [{"namedRangeId": "n", "name": "range"}]
+}`
+
+func TestReadSpreadsheet(t *testing.T) {
+	assert := assert.New(t)
+	spreadsheet, err := readSpreadsheet(strings.NewReader(testSpreadsheetJSON), false)
+	if assert.NoError(err) {
+		assert.Equal("abc", spreadsheet.ID)
+		assert.Equal("Book", spreadsheet.Properties.Title)
+		assert.Equal([]NamedRange{{NamedRangeID: "n", Name: "range"}}, spreadsheet.NamedRanges)
+		if assert.Len(spreadsheet.Sheets, 2) {
+			assert.Equal("b", spreadsheet.Sheets[0].Rows[0][1].Value)
+			assert.Equal("two", spreadsheet.Sheets[1].Properties.Title)
+			assert.Contains(string(spreadsheet.Sheets[0].TmpData), `"title": "one"`)
+			assert.Contains(string(spreadsheet.Sheets[1].TmpData), `"title": "two"`)
+		}
+	}
+
+	spreadsheet, err = readSpreadsheet(strings.NewReader(`{"spreadsheetId":"abc","sheets":null}`), false)
+	assert.NoError(err)
+	assert.Equal("abc", spreadsheet.ID)
+	assert.Empty(spreadsheet.Sheets)
+
+	_, err = readSpreadsheet(strings.NewReader(`{"sheets":{}}`), false)
+	assert.Error(err)
+	_, err = readSpreadsheet(strings.NewReader(`[]`), false)
+	assert.Error(err)
+	_, err = readSpreadsheet(strings.NewReader(`{"sheets":[{}`), false)
+	assert.Error(err)
+}
+
+func TestFetchSpreadsheetStreaming(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(testSpreadsheetJSON))
+		gz.Close()
+	}))
+	defer server.Close()
+
+	for _, debug := range []bool{false, true} {
+		opts := []ServiceOption{WithBaseURL(server.URL), WithLogger(&testLogger{})}
+		if debug {
+			opts = append(opts, WithDebug())
+		}
+		s := NewServiceWithClient(server.Client(), opts...)
+		spreadsheet, err := s.FetchSpreadsheet("abc")
+		if assert.NoError(err) && assert.Len(spreadsheet.Sheets, 2) {
+			assert.Equal("a", spreadsheet.Sheets[0].Rows[0][0].Value)
+		}
+		usage := s.Usage()
+		assert.Equal(1, usage.TotalCalls())
+		assert.Equal(2, usage.CellsRead)
+	}
+}
+
+func TestFetchSpreadsheetAndSynchronize(t *testing.T) {
+	assert := assert.New(t)
+	var updates int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/spreadsheets/abc":
+			w.Write([]byte(testSpreadsheetJSON))
+		case r.Method == http.MethodPost && r.URL.Path == "/spreadsheets/abc:batchUpdate":
+			// the sheets of the spreadsheet have no grid properties, so they are expanded
+			w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/spreadsheets/abc/values:batchUpdate":
+			updates++
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+
+	fetches := map[string]func() (Spreadsheet, error){
+		"FetchSpreadsheet":         func() (Spreadsheet, error) { return s.FetchSpreadsheet("abc") },
+		"FetchSpreadsheetMetadata": func() (Spreadsheet, error) { return s.FetchSpreadsheetMetadata("abc") },
+		"FetchSpreadsheetConcurrently": func() (Spreadsheet, error) {
+			return s.FetchSpreadsheetConcurrently("abc", nil, 2)
+		},
+	}
+	for name, fetch := range fetches {
+		spreadsheet, err := fetch()
+		if !assert.NoError(err, name) {
+			continue
+		}
+		sheet, err := spreadsheet.SheetByIndex(0)
+		assert.NoError(err, name)
+		assert.NoError(sheet.Update(0, 0, "x"), name)
+		assert.NotPanics(func() { assert.NoError(sheet.Synchronize(), name) }, name)
+	}
+	assert.Equal(len(fetches), updates)
+}
@@ -0,0 +1,29 @@
+package spreadsheet
+
+// ThemeColorType is a type of a theme color.
+type ThemeColorType string
+
+// Theme color types.
+const (
+	ThemeColorText       ThemeColorType = "TEXT"
+	ThemeColorBackground ThemeColorType = "BACKGROUND"
+	ThemeColorAccent1    ThemeColorType = "ACCENT1"
+	ThemeColorAccent2    ThemeColorType = "ACCENT2"
+	ThemeColorAccent3    ThemeColorType = "ACCENT3"
+	ThemeColorAccent4    ThemeColorType = "ACCENT4"
+	ThemeColorAccent5    ThemeColorType = "ACCENT5"
+	ThemeColorAccent6    ThemeColorType = "ACCENT6"
+	ThemeColorLink       ThemeColorType = "LINK"
+)
+
+// SpreadsheetTheme is a theme of a spreadsheet.
+type SpreadsheetTheme struct {
+	PrimaryFontFamily string           `json:"primaryFontFamily,omitempty"`
+	ThemeColors       []ThemeColorPair `json:"themeColors,omitempty"`
+}
+
+// ThemeColorPair is a pair mapping a theme color type to the concrete color it represents.
+type ThemeColorPair struct {
+	ColorType ThemeColorType `json:"colorType"`
+	Color     ColorStyle     `json:"color"`
+}
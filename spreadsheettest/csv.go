@@ -0,0 +1,137 @@
+// Package spreadsheettest provides utilities for testing code which uses the spreadsheet package.
+package spreadsheettest
+
+import (
+	"encoding/csv"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Kayuii/spreadsheet"
+)
+
+// CSVOptions configures how a sheet is compared with a CSV baseline.
+type CSVOptions struct {
+	// TrimSpace ignores leading and trailing white space of the values.
+	TrimSpace bool
+	// NumericEpsilon is the tolerance used when both values are numbers.
+	NumericEpsilon float64
+	// IgnoreColumns are the zero based indexes of columns not to compare.
+	IgnoreColumns []int
+}
+
+// AssertSheetMatchesCSV asserts that the cells of the sheet match the CSV file at the path.
+// Trailing empty rows and columns are ignored on both sides.
+// It reports every mismatching cell and returns whether the sheet matches.
+func AssertSheetMatchesCSV(t testing.TB, sheet *spreadsheet.Sheet, path string, opts CSVOptions) bool {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Errorf("unable to open the CSV baseline: %v", err)
+		return false
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	expected, err := r.ReadAll()
+	if err != nil {
+		t.Errorf("unable to read the CSV baseline %s: %v", path, err)
+		return false
+	}
+	expected = trimRecords(expected)
+
+	actual := make([][]string, len(sheet.Rows))
+	for i, row := range sheet.Rows {
+		actual[i] = make([]string, len(row))
+		for j, cell := range row {
+			actual[i][j] = cell.Value
+		}
+	}
+	actual = trimRecords(actual)
+
+	ignored := make(map[int]bool, len(opts.IgnoreColumns))
+	for _, column := range opts.IgnoreColumns {
+		ignored[column] = true
+	}
+
+	ok := true
+	rows := max(len(expected), len(actual))
+	for row := 0; row < rows; row++ {
+		columns := max(len(valueAt(expected, row)), len(valueAt(actual, row)))
+		for column := 0; column < columns; column++ {
+			if ignored[column] {
+				continue
+			}
+			want := get(expected, row, column)
+			got := get(actual, row, column)
+			if opts.match(want, got) {
+				continue
+			}
+			cell := spreadsheet.Cell{Row: uint(row), Column: uint(column)}
+			t.Errorf("%s: expected %q but got %q", cell.Pos(), want, got)
+			ok = false
+		}
+	}
+	return ok
+}
+
+func (opts CSVOptions) match(want, got string) bool {
+	if opts.TrimSpace {
+		want, got = strings.TrimSpace(want), strings.TrimSpace(got)
+	}
+	if want == got {
+		return true
+	}
+	if opts.NumericEpsilon <= 0 {
+		return false
+	}
+	w, err := strconv.ParseFloat(want, 64)
+	if err != nil {
+		return false
+	}
+	g, err := strconv.ParseFloat(got, 64)
+	if err != nil {
+		return false
+	}
+	return math.Abs(w-g) <= opts.NumericEpsilon
+}
+
+// trimRecords drops trailing empty values and rows.
+func trimRecords(records [][]string) [][]string {
+	for i, record := range records {
+		n := len(record)
+		for n > 0 && record[n-1] == "" {
+			n--
+		}
+		records[i] = record[:n]
+	}
+	n := len(records)
+	for n > 0 && len(records[n-1]) == 0 {
+		n--
+	}
+	return records[:n]
+}
+
+func valueAt(records [][]string, row int) []string {
+	if row < len(records) {
+		return records[row]
+	}
+	return nil
+}
+
+func get(records [][]string, row, column int) string {
+	record := valueAt(records, row)
+	if column < len(record) {
+		return record[column]
+	}
+	return ""
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
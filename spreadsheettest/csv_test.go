@@ -0,0 +1,71 @@
+package spreadsheettest
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Kayuii/spreadsheet"
+	"github.com/stretchr/testify/assert"
+)
+
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func newSheet(t *testing.T, rows ...[]string) *spreadsheet.Sheet {
+	rowData := make([]map[string]interface{}, len(rows))
+	for i, row := range rows {
+		values := make([]map[string]interface{}, len(row))
+		for j, v := range row {
+			values[j] = map[string]interface{}{"formattedValue": v}
+		}
+		rowData[i] = map[string]interface{}{"values": values}
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"data": []map[string]interface{}{{"rowData": rowData}},
+	})
+	assert.NoError(t, err)
+	sheet := &spreadsheet.Sheet{}
+	assert.NoError(t, json.Unmarshal(data, sheet))
+	return sheet
+}
+
+func TestAssertSheetMatchesCSV(t *testing.T) {
+	assert := assert.New(t)
+	sheet := newSheet(t,
+		[]string{"name", "score", "updated"},
+		[]string{"alice", "1", "2019-01-03"},
+		[]string{"bob", "2", "2019-01-02", ""},
+		[]string{},
+	)
+
+	r := &recorder{TB: t}
+	assert.False(AssertSheetMatchesCSV(r, sheet, "testdata/baseline.csv", CSVOptions{}))
+	assert.Equal([]string{
+		`B2: expected "1.0001" but got "1"`,
+		`C2: expected "2019-01-01" but got "2019-01-03"`,
+		`A3: expected "bob " but got "bob"`,
+	}, r.errors)
+
+	r = &recorder{TB: t}
+	assert.True(AssertSheetMatchesCSV(r, sheet, "testdata/baseline.csv", CSVOptions{
+		TrimSpace:      true,
+		NumericEpsilon: 0.001,
+		IgnoreColumns:  []int{2},
+	}))
+	assert.Empty(r.errors)
+}
+
+func TestAssertSheetMatchesCSVMissingFile(t *testing.T) {
+	r := &recorder{TB: t}
+	assert.False(t, AssertSheetMatchesCSV(r, &spreadsheet.Sheet{}, "testdata/missing.csv", CSVOptions{}))
+	assert.Len(t, r.errors, 1)
+}
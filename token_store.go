@@ -0,0 +1,63 @@
+package spreadsheet
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrNoToken is returned by TokenStore.Load when no token has been saved yet.
+var ErrNoToken = errors.New("no token saved")
+
+// TokenStore keeps the OAuth token of the user of a CLI between runs, see NewServiceForCLI.
+type TokenStore interface {
+	// Load returns the saved token, or ErrNoToken.
+	Load() (*oauth2.Token, error)
+	Save(token *oauth2.Token) error
+}
+
+// FileTokenStore keeps the token as JSON in the file at the path, readable by its owner only.
+type FileTokenStore struct {
+	Path string
+}
+
+// Load reads the token from the file.
+func (s FileTokenStore) Load() (token *oauth2.Token, err error) {
+	data, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		err = ErrNoToken
+		return
+	}
+	if err != nil {
+		return
+	}
+	token = &oauth2.Token{}
+	err = json.Unmarshal(data, token)
+	return
+}
+
+// Save writes the token to the file, making its directory when needed.
+func (s FileTokenStore) Save(token *oauth2.Token) (err error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return
+	}
+	err = os.MkdirAll(filepath.Dir(s.Path), 0700)
+	if err != nil {
+		return
+	}
+	err = ioutil.WriteFile(s.Path, data, 0600)
+	return
+}
+
+// WithTokenStore keeps the token of NewServiceForCLI in the store instead of token.json
+// in the working directory, e.g. in a FileTokenStore in the configuration directory of the user.
+func WithTokenStore(store TokenStore) ServiceOption {
+	return func(s *serviceSettings) {
+		s.tokenStore = store
+	}
+}
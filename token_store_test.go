@@ -0,0 +1,40 @@
+package spreadsheet
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestFileTokenStore(t *testing.T) {
+	assert := assert.New(t)
+	dir, err := ioutil.TempDir("", "spreadsheet")
+	if !assert.NoError(err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	store := FileTokenStore{Path: filepath.Join(dir, "spreadsheet", "token.json")}
+
+	_, err = store.Load()
+	assert.Equal(ErrNoToken, err)
+
+	token := &oauth2.Token{
+		AccessToken:  "access",
+		TokenType:    "Bearer",
+		RefreshToken: "refresh",
+		Expiry:       time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	assert.NoError(store.Save(token))
+	info, err := os.Stat(store.Path)
+	if assert.NoError(err) {
+		assert.Equal(os.FileMode(0600), info.Mode().Perm())
+	}
+	loaded, err := store.Load()
+	assert.NoError(err)
+	assert.Equal(token, loaded)
+}
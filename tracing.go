@@ -0,0 +1,53 @@
+package spreadsheet
+
+import "context"
+
+// TracerName is the name of the tracer of the service given to the TracerProvider.
+const TracerName = "github.com/Kayuii/spreadsheet"
+
+// TracerProvider provides the tracer of the API calls. It follows the TracerProvider of
+// OpenTelemetry, which is plugged in by a small adapter without this package depending on it.
+type TracerProvider interface {
+	Tracer(name string) Tracer
+}
+
+// Tracer starts a span around every API call, retries included.
+// The name of the span is the endpoint, e.g. "GET /spreadsheets/{spreadsheetId}/values/{range}",
+// and the attributes are "http.method", "spreadsheet.id" and "spreadsheet.range" when known.
+type Tracer interface {
+	Start(ctx context.Context, name string, attributes map[string]string) (context.Context, Span)
+}
+
+// Span is the span of an API call.
+type Span interface {
+	// End ends the span with the HTTP status of the last response, 0 if none,
+	// and the error of the call, if any.
+	End(status int, err error)
+}
+
+// WithTracerProvider traces the API calls with the tracer provided.
+func WithTracerProvider(provider TracerProvider) ServiceOption {
+	return func(s *serviceSettings) {
+		s.tracer = provider.Tracer(TracerName)
+	}
+}
+
+// startSpan starts the span of the call with the tracer of the service, if any.
+func (s *Service) startSpan(ctx context.Context, method, url string) (context.Context, Span) {
+	if s.tracer == nil {
+		return ctx, nopSpan{}
+	}
+	name, ids := parseEndpoint(method, url)
+	attributes := map[string]string{"http.method": method}
+	if id, ok := ids["spreadsheetId"]; ok {
+		attributes["spreadsheet.id"] = id
+	}
+	if r, ok := ids["range"]; ok {
+		attributes["spreadsheet.range"] = r
+	}
+	return s.tracer.Start(ctx, name, attributes)
+}
+
+type nopSpan struct{}
+
+func (nopSpan) End(status int, err error) {}
@@ -0,0 +1,76 @@
+package spreadsheet
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+func (t *testTracer) Tracer(name string) Tracer {
+	return t
+}
+
+func (t *testTracer) Start(ctx context.Context, name string, attributes map[string]string) (context.Context, Span) {
+	span := &testSpan{name: name, attributes: attributes}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+type testSpan struct {
+	name       string
+	attributes map[string]string
+	status     int
+	err        error
+	ended      bool
+}
+
+func (s *testSpan) End(status int, err error) {
+	s.status, s.err, s.ended = status, err, true
+}
+
+func TestTracing(t *testing.T) {
+	assert := assert.New(t)
+	statuses := []int{http.StatusServiceUnavailable, http.StatusOK, http.StatusNotFound}
+	client := &http.Client{Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		status := statuses[0]
+		statuses = statuses[1:]
+		if status != http.StatusOK {
+			return jsonResponse(status, `{"error":{"message":"failed"}}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"values":[]}`), nil
+	})}
+	tracer := &testTracer{}
+	s := NewServiceWithClient(client, WithTracerProvider(tracer))
+	s.SetRetryPolicy(RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond})
+
+	_, err := s.GetValues("abc", "Sheet 1!A1:B2")
+	assert.NoError(err)
+	_, err = s.ClearValues("abc", "A1")
+	assert.Error(err)
+
+	if assert.Len(tracer.spans, 2) {
+		span := tracer.spans[0]
+		assert.Equal("GET /spreadsheets/{spreadsheetId}/values/{range}", span.name)
+		assert.Equal(map[string]string{
+			"http.method":       "GET",
+			"spreadsheet.id":    "abc",
+			"spreadsheet.range": "Sheet 1!A1:B2",
+		}, span.attributes)
+		assert.Equal(http.StatusOK, span.status)
+		assert.NoError(span.err)
+		assert.True(span.ended)
+
+		span = tracer.spans[1]
+		assert.Equal("POST /spreadsheets/{spreadsheetId}/values/{range}:clear", span.name)
+		assert.Equal("A1", span.attributes["spreadsheet.range"])
+		assert.Equal(http.StatusNotFound, span.status)
+		assert.Error(span.err)
+	}
+}
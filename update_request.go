@@ -1,9 +1,13 @@
 package spreadsheet
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"net/http"
+	"net/url"
 	"strings"
 )
 
@@ -24,19 +28,103 @@ func newUpdateRequest(spreadsheet *Spreadsheet) (r *updateRequest, err error) {
 type updateRequest struct {
 	spreadsheet *Spreadsheet
 	body        map[string][]map[string]interface{}
+	// err is the first error found while building the requests, returned by Do.
+	err error
+	// includeSpreadsheet asks for the updated spreadsheet in the response, which do decodes into
+	// the spreadsheet, with the cells of the responseRanges if responseIncludeGridData is set.
+	includeSpreadsheet      bool
+	responseRanges          []string
+	responseIncludeGridData bool
+}
+
+// check records the error found while building a request.
+func (r *updateRequest) check(err error) {
+	if r.err == nil {
+		r.err = err
+	}
+}
+
+// IncludeSpreadsheetInResponse makes Do reload the spreadsheet from the response of the batch update,
+// in the same round trip. The cells of the ranges, or of all the sheets when there are none,
+// are reloaded only if includeGridData is set.
+func (r *updateRequest) IncludeSpreadsheetInResponse(ranges []string, includeGridData bool) *updateRequest {
+	r.includeSpreadsheet = true
+	r.responseRanges = ranges
+	r.responseIncludeGridData = includeGridData
+	return r
+}
+
+// doAndReload sends the requests and reloads the spreadsheet like ReloadSpreadsheet, from the response,
+// unless the options skip the reload.
+func (r *updateRequest) doAndReload(ctx context.Context, opts []CallOption) (replies []Reply, err error) {
+	o := newCallOptions(r.spreadsheet.service.defaultOptions, r.spreadsheet.defaultOptions, opts)
+	ctx, cancel := r.spreadsheet.service.withCallOptions(ctx, append(append([]CallOption{}, r.spreadsheet.defaultOptions...), opts...))
+	defer cancel()
+	if o.skipReload {
+		replies, err = r.Do(ctx)
+		if err == nil {
+			r.spreadsheet.stale = true
+		}
+		return
+	}
+	replies, err = r.IncludeSpreadsheetInResponse(nil, !o.lazyLoading).Do(ctx)
+	return
 }
 
-func (r *updateRequest) Do() (err error) {
+// Do sends the requests in a batch update and returns their replies, in the order of the requests.
+func (r *updateRequest) Do(ctx context.Context) (replies []Reply, err error) {
+	replies, err = r.do(ctx, nil)
+	return
+}
+
+func (r *updateRequest) do(ctx context.Context, t *transfer) (replies []Reply, err error) {
+	if r.err != nil {
+		err = r.err
+		return
+	}
 	if len(r.body["requests"]) == 0 {
 		err = errors.New("Requests must not be empty")
 		return
 	}
+	s := r.spreadsheet.service
 	path := fmt.Sprintf("/spreadsheets/%s:batchUpdate", r.spreadsheet.ID)
 	params := make(map[string]interface{}, len(r.body))
 	for k, v := range r.body {
 		params[k] = v
 	}
-	_, err = r.spreadsheet.service.post(path, params)
+	if r.includeSpreadsheet {
+		params["includeSpreadsheetInResponse"] = true
+		params["responseIncludeGridData"] = r.responseIncludeGridData
+		if len(r.responseRanges) > 0 {
+			params["responseRanges"] = r.responseRanges
+		}
+		fields := metadataFields
+		if r.responseIncludeGridData {
+			fields = s.spreadsheetFields(r.spreadsheet.defaultOptions)
+		}
+		path += "?" + url.Values{"fields": {"replies,updatedSpreadsheet(" + fields + ")"}}.Encode()
+	}
+	body, err := s.send(ctx, t, http.MethodPost, path, params)
+	if err != nil {
+		return
+	}
+	var resp struct {
+		Replies            []Reply         `json:"replies"`
+		UpdatedSpreadsheet json.RawMessage `json:"updatedSpreadsheet"`
+	}
+	err = json.Unmarshal([]byte(body), &resp)
+	if err != nil {
+		return
+	}
+	replies = resp.Replies
+	if r.includeSpreadsheet && len(resp.UpdatedSpreadsheet) > 0 {
+		var updated Spreadsheet
+		updated, err = s.decodeSpreadsheet(bytes.NewReader(resp.UpdatedSpreadsheet), r.spreadsheet.defaultOptions)
+		if err != nil {
+			return
+		}
+		r.spreadsheet.reload(updated)
+	}
 	return
 }
 
@@ -53,12 +141,16 @@ func (r *updateRequest) UpdateSpreadsheetProperties(spreadsheetProperties *Prope
 		fields = append(fields, "locale")
 	}
 	if spreadsheetProperties.AutoRecalc != "" {
-		params["autoRecalc"] = spreadsheetProperties.Locale
+		params["autoRecalc"] = spreadsheetProperties.AutoRecalc
 		fields = append(fields, "autoRecalc")
 	}
 	if spreadsheetProperties.TimeZone != "" {
-		params["timezone"] = spreadsheetProperties.Locale
-		fields = append(fields, "timezone")
+		params["timeZone"] = spreadsheetProperties.TimeZone
+		fields = append(fields, "timeZone")
+	}
+	if spreadsheetProperties.SpreadsheetTheme != nil {
+		params["spreadsheetTheme"] = spreadsheetProperties.SpreadsheetTheme
+		fields = append(fields, "spreadsheetTheme")
 	}
 	if len(fields) == 0 {
 		return
@@ -136,16 +228,55 @@ func (r *updateRequest) UpdateSheetProperties(sheet *Sheet, sheetProperties *She
 	return
 }
 
-func (r *updateRequest) UpdateDimensionProperties() {
+// UpdateGridSize sets the number of rows and columns of the sheet with the ID
+func (r *updateRequest) UpdateGridSize(sheetID, rowCount, columnCount uint) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateSheetProperties": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"sheetId": sheetID,
+				"gridProperties": map[string]interface{}{
+					"rowCount":    rowCount,
+					"columnCount": columnCount,
+				},
+			},
+			"fields": "gridProperties.rowCount,gridProperties.columnCount",
+		},
+	})
+	return r
+}
 
+// UpdateDimensionProperties updates properties of rows or columns
+func (r *updateRequest) UpdateDimensionProperties(sheet *Sheet, dimension Dimension, start, end int, properties *DimensionProperties, fields string) (ret *updateRequest) {
+	r.check(dimension.validate())
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateDimensionProperties": map[string]interface{}{
+			"range": map[string]interface{}{
+				"sheetId":    sheet.Properties.ID,
+				"dimension":  dimension,
+				"startIndex": start,
+				"endIndex":   end,
+			},
+			"properties": properties,
+			"fields":     fields,
+		},
+	})
+	return r
 }
 
 func (r *updateRequest) UpdateNamedRange() {
 
 }
 
-func (r *updateRequest) RepeatCell() {
-
+// RepeatCell updates the fields of all the cells in the range with the ones of the cell
+func (r *updateRequest) RepeatCell(gridRange GridRange, cell CellData, fields string) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"repeatCell": map[string]interface{}{
+			"range":  gridRange,
+			"cell":   cell,
+			"fields": fields,
+		},
+	})
+	return r
 }
 
 func (r *updateRequest) AddNamedRange() {
@@ -182,12 +313,31 @@ func (r *updateRequest) CutPaste() {
 
 }
 
-func (r *updateRequest) CopyPaste() {
-
+// CopyPaste copies the data of the source range to the destination range,
+// repeating the source to fill the destination.
+func (r *updateRequest) CopyPaste(source, destination GridRange, pasteType PasteType) *updateRequest {
+	r.check(pasteType.validate())
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"copyPaste": map[string]interface{}{
+			"source":           source,
+			"destination":      destination,
+			"pasteType":        pasteType,
+			"pasteOrientation": "NORMAL",
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) MergeCells() {
-
+// MergeCells merges the cells in the range
+func (r *updateRequest) MergeCells(gridRange GridRange, mergeType MergeType) *updateRequest {
+	r.check(mergeType.validate())
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"mergeCells": map[string]interface{}{
+			"range":     gridRange,
+			"mergeType": mergeType,
+		},
+	})
+	return r
 }
 
 func (r *updateRequest) UnmergeCells() {
@@ -198,8 +348,16 @@ func (r *updateRequest) UpdateBorders() {
 
 }
 
-func (r *updateRequest) UpdateCells() {
-
+// UpdateCells updates the fields of the cells starting at the coordinate
+func (r *updateRequest) UpdateCells(start GridCoordinate, rows []RowData, fields string) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateCells": map[string]interface{}{
+			"start":  start,
+			"rows":   rows,
+			"fields": fields,
+		},
+	})
+	return r
 }
 
 func (r *updateRequest) AddFilterView() {
@@ -207,21 +365,6 @@ func (r *updateRequest) AddFilterView() {
 }
 
 func (r *updateRequest) AppendCells(sheet *Sheet, rows [][]Cell) *updateRequest {
-
-	// _rows := make([]interface{}, len(rows))
-	for k, v := range rows {
-
-		log.Printf("kv key: %v  value: %v", k, v)
-
-		// _rows = append(_rows, )
-		// record := make()
-		for a, b := range v {
-			log.Printf("ab key: %v  value: %v", a, b)
-			// record = append(record, b.Value)
-		}
-
-	}
-
 	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
 		"appendCells": map[string]interface{}{
 			"sheetId": sheet.Properties.ID,
@@ -237,7 +380,8 @@ func (r *updateRequest) ClearBasicFilter() {
 }
 
 // DeleteDemension deletes rows or columns
-func (r *updateRequest) DeleteDimension(sheet *Sheet, dimension string, start, end int) (ret *updateRequest) {
+func (r *updateRequest) DeleteDimension(sheet *Sheet, dimension Dimension, start, end int) (ret *updateRequest) {
+	r.check(dimension.validate())
 	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
 		"deleteDimension": map[string]interface{}{
 			"range": map[string]interface{}{
@@ -267,11 +411,23 @@ func (r *updateRequest) DuplicateSheet() {
 
 }
 
-func (r *updateRequest) FindReplace() {
-
+// FindReplace finds and replaces text in all sheets
+func (r *updateRequest) FindReplace(find, replacement string, searchByRegex, includeFormulas bool) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"findReplace": map[string]interface{}{
+			"find":            find,
+			"replacement":     replacement,
+			"matchCase":       true,
+			"searchByRegex":   searchByRegex,
+			"includeFormulas": includeFormulas,
+			"allSheets":       true,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) InsertDimension(sheet *Sheet, dimension string, start, end int) (ret *updateRequest) {
+func (r *updateRequest) InsertDimension(sheet *Sheet, dimension Dimension, start, end int) (ret *updateRequest) {
+	r.check(dimension.validate())
 	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
 		"insertDimension": map[string]interface{}{
 			"range": map[string]interface{}{
@@ -302,12 +458,31 @@ func (r *updateRequest) TextToColumns() {
 
 }
 
-func (r *updateRequest) UpdateFilterView() {
-
+// UpdateFilterView updates the fields of the filter view
+func (r *updateRequest) UpdateFilterView(filterView FilterView, fields string) *updateRequest {
+	for _, sortSpec := range filterView.SortSpecs {
+		r.check(sortSpec.SortOrder.validate())
+	}
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateFilterView": map[string]interface{}{
+			"filter": filterView,
+			"fields": fields,
+		},
+	})
+	return r
 }
 
-func (r *updateRequest) AppendDimension() {
-
+// AppendDimension appends rows or columns to the end of a sheet
+func (r *updateRequest) AppendDimension(sheet *Sheet, dimension Dimension, length int) (ret *updateRequest) {
+	r.check(dimension.validate())
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"appendDimension": map[string]interface{}{
+			"sheetId":   sheet.Properties.ID,
+			"dimension": dimension,
+			"length":    length,
+		},
+	})
+	return r
 }
 
 func (r *updateRequest) AddConditionalFormatRule() {
@@ -326,8 +501,19 @@ func (r *updateRequest) SortRange() {
 
 }
 
-func (r *updateRequest) SetDataValidation() {
-
+// SetDataValidation sets the rule of data validation of the cells in the range, or removes it when the rule is nil.
+func (r *updateRequest) SetDataValidation(gridRange GridRange, rule *DataValidationRule) *updateRequest {
+	params := map[string]interface{}{
+		"range": gridRange,
+	}
+	if rule != nil {
+		r.check(rule.Condition.Type.validate())
+		params["rule"] = rule
+	}
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"setDataValidation": params,
+	})
+	return r
 }
 
 func (r *updateRequest) SetBasicFilter() {
@@ -338,8 +524,15 @@ func (r *updateRequest) AddProtectedRange() {
 
 }
 
-func (r *updateRequest) UpdateProtectedRange() {
-
+// UpdateProtectedRange updates the fields of the protected range
+func (r *updateRequest) UpdateProtectedRange(protectedRange ProtectedRange, fields string) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"updateProtectedRange": map[string]interface{}{
+			"protectedRange": protectedRange,
+			"fields":         fields,
+		},
+	})
+	return r
 }
 
 func (r *updateRequest) DeleteProtectedRange() {
@@ -350,8 +543,17 @@ func (r *updateRequest) AutoResizeDimensions() {
 
 }
 
-func (r *updateRequest) AddChart() {
-
+// AddChart adds a chart
+func (r *updateRequest) AddChart(chart EmbeddedChart) *updateRequest {
+	if chart.Spec.BasicChart != nil {
+		r.check(chart.Spec.BasicChart.validate())
+	}
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"addChart": map[string]interface{}{
+			"chart": chart,
+		},
+	})
+	return r
 }
 
 func (r *updateRequest) UpdateChartSpec() {
@@ -366,6 +568,12 @@ func (r *updateRequest) AddBanding() {
 
 }
 
-func (r *updateRequest) DeleteBanding() {
-
+// DeleteBanding deletes the banded range with the ID
+func (r *updateRequest) DeleteBanding(bandedRangeID int) *updateRequest {
+	r.body["requests"] = append(r.body["requests"], map[string]interface{}{
+		"deleteBanding": map[string]interface{}{
+			"bandedRangeId": bandedRangeID,
+		},
+	})
+	return r
 }
@@ -0,0 +1,206 @@
+package spreadsheet
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateSpreadsheetProperties(t *testing.T) {
+	assert := assert.New(t)
+	r, err := newUpdateRequest(&Spreadsheet{})
+	assert.NoError(err)
+
+	theme := &SpreadsheetTheme{
+		PrimaryFontFamily: "Roboto",
+		ThemeColors: []ThemeColorPair{
+			{ColorType: ThemeColorAccent1, Color: ColorStyle{RGBColor: &Color{Red: 1}}},
+		},
+	}
+	r.UpdateSpreadsheetProperties(&Properties{TimeZone: "Asia/Tokyo", SpreadsheetTheme: theme})
+	assert.Len(r.body["requests"], 1)
+	req := r.body["requests"][0]["updateSpreadsheetProperties"].(map[string]interface{})
+	assert.Equal("timeZone,spreadsheetTheme", req["fields"])
+	params := req["properties"].(map[string]interface{})
+	assert.Equal("Asia/Tokyo", params["timeZone"])
+	assert.Equal(theme, params["spreadsheetTheme"])
+
+	r.UpdateSpreadsheetProperties(&Properties{})
+	assert.Len(r.body["requests"], 1)
+}
+
+func TestCopyPaste(t *testing.T) {
+	assert := assert.New(t)
+	r, err := newUpdateRequest(&Spreadsheet{})
+	assert.NoError(err)
+	source := GridRange{SheetID: 1, StartRowIndex: 2, EndRowIndex: 3}
+	destination := GridRange{SheetID: 1, StartRowIndex: 3, EndRowIndex: 5}
+	r.CopyPaste(source, destination, PasteNormal)
+	req := r.body["requests"][0]["copyPaste"].(map[string]interface{})
+	assert.Equal(source, req["source"])
+	assert.Equal(destination, req["destination"])
+	assert.Equal(PasteNormal, req["pasteType"])
+	assert.NoError(r.err)
+
+	r.CopyPaste(source, destination, "PASTE_ALL")
+	assert.Error(r.err)
+}
+
+func TestUpdateRequestReplies(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal("/spreadsheets/abc:batchUpdate", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"spreadsheetId":"abc","replies":[
+			{"addSheet":{"properties":{"sheetId":12,"title":"New"}}},
+			{},
+			{"findReplace":{"valuesChanged":2,"occurrencesChanged":3}}
+		]}`))
+	}))
+	defer server.Close()
+
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	r, err := newUpdateRequest(&Spreadsheet{ID: "abc", service: s})
+	assert.NoError(err)
+	replies, err := r.AddSheet(SheetProperties{Title: "New"}).
+		DeleteSheet(1).
+		FindReplace("a", "b", false, false).
+		Do(context.Background())
+	if assert.NoError(err) && assert.Len(replies, 3) {
+		assert.Equal(uint(12), replies[0].AddSheet.Properties.ID)
+		assert.Equal(Reply{}, replies[1])
+		assert.Equal(3, replies[2].FindReplace.OccurrencesChanged)
+	}
+}
+
+func TestUpdateRequestIncludeSpreadsheetInResponse(t *testing.T) {
+	assert := assert.New(t)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var body map[string]interface{}
+		assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(true, body["includeSpreadsheetInResponse"])
+		assert.Equal(true, body["responseIncludeGridData"])
+		assert.Contains(r.URL.Query().Get("fields"), "replies,updatedSpreadsheet(spreadsheetId,properties,sheets(")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"replies":[{"addSheet":{"properties":{"sheetId":2,"title":"New"}}}],
+			"updatedSpreadsheet":{"spreadsheetId":"abc","properties":{"title":"Book"},"sheets":[
+				{"properties":{"sheetId":1,"title":"Sheet1"}},
+				{"properties":{"sheetId":2,"title":"New","index":1}}
+			]}}`))
+	}))
+	defer server.Close()
+
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	spreadsheet := &Spreadsheet{ID: "abc", service: s, Sheets: []Sheet{{Properties: SheetProperties{ID: 1, Title: "Sheet1"}}}}
+	reply, err := s.AddSheetReply(spreadsheet, SheetProperties{Title: "New"})
+	if assert.NoError(err) {
+		assert.Equal(uint(2), reply.Properties.ID)
+		assert.Equal("New", reply.Properties.Title)
+	}
+	assert.Equal(1, requests)
+	assert.Equal("Book", spreadsheet.Properties.Title)
+	if assert.Len(spreadsheet.Sheets, 2) {
+		assert.Equal("New", spreadsheet.Sheets[1].Properties.Title)
+		assert.True(spreadsheet == spreadsheet.Sheets[1].Spreadsheet)
+	}
+}
+
+func TestWithoutReload(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+		assert.Nil(body["includeSpreadsheetInResponse"])
+		assert.Empty(r.URL.Query().Get("fields"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"replies":[{"addSheet":{"properties":{"sheetId":2,"title":"New"}}}]}`))
+	}))
+	defer server.Close()
+
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	spreadsheet := &Spreadsheet{ID: "abc", service: s, Sheets: []Sheet{{Properties: SheetProperties{ID: 1, Title: "Sheet1"}}}}
+	assert.False(spreadsheet.NeedsReload())
+	assert.NoError(s.AddSheet(spreadsheet, SheetProperties{Title: "New"}, WithoutReload()))
+	assert.Len(spreadsheet.Sheets, 1)
+	assert.True(spreadsheet.NeedsReload())
+
+	spreadsheet.reload(Spreadsheet{})
+	assert.False(spreadsheet.NeedsReload())
+	spreadsheet.SetDefaultCallOptions(WithoutReload())
+	assert.NoError(s.DeleteSheet(spreadsheet, 1))
+	assert.True(spreadsheet.NeedsReload())
+}
+
+func TestSetCheckboxes(t *testing.T) {
+	assert := assert.New(t)
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	spreadsheet := &Spreadsheet{ID: "abc", service: s, Sheets: []Sheet{{Properties: SheetProperties{ID: 7, Title: "Tasks"}}}}
+	sheet := &spreadsheet.Sheets[0]
+	sheet.Spreadsheet = spreadsheet
+	assert.NoError(s.SetCheckboxes(sheet, GridRange{StartRowIndex: 1, EndRowIndex: 10, StartColumnIndex: 2, EndColumnIndex: 3}))
+	assert.Equal(map[string]interface{}{"requests": []interface{}{map[string]interface{}{"setDataValidation": map[string]interface{}{
+		"range": map[string]interface{}{"sheetId": 7.0, "startRowIndex": 1.0, "endRowIndex": 10.0, "startColumnIndex": 2.0, "endColumnIndex": 3.0},
+		"rule":  map[string]interface{}{"condition": map[string]interface{}{"type": "BOOLEAN"}},
+	}}}}, body)
+
+	assert.NoError(s.SetDataValidation(sheet, GridRange{}, nil))
+	assert.Equal(map[string]interface{}{"requests": []interface{}{map[string]interface{}{"setDataValidation": map[string]interface{}{
+		"range": map[string]interface{}{"sheetId": 7.0},
+	}}}}, body)
+	assert.Error(s.SetDataValidation(sheet, GridRange{}, &DataValidationRule{Condition: BooleanCondition{Type: "CHECKBOX"}}))
+}
+
+func TestSetDropdown(t *testing.T) {
+	assert := assert.New(t)
+	var rule interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Requests []struct {
+				SetDataValidation struct {
+					Rule interface{} `json:"rule"`
+				} `json:"setDataValidation"`
+			} `json:"requests"`
+		}
+		assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+		rule = body.Requests[0].SetDataValidation.Rule
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	spreadsheet := &Spreadsheet{ID: "abc", service: s, Sheets: []Sheet{{Properties: SheetProperties{ID: 7, Title: "Tasks"}}}}
+	sheet := &spreadsheet.Sheets[0]
+	sheet.Spreadsheet = spreadsheet
+	column := GridRange{StartRowIndex: 1, StartColumnIndex: 3, EndColumnIndex: 4}
+	assert.NoError(s.SetDropdown(sheet, column, []string{"todo", "done"}))
+	assert.Equal(map[string]interface{}{
+		"condition": map[string]interface{}{"type": "ONE_OF_LIST", "values": []interface{}{
+			map[string]interface{}{"userEnteredValue": "todo"},
+			map[string]interface{}{"userEnteredValue": "done"},
+		}},
+		"showCustomUi": true,
+	}, rule)
+	assert.NoError(s.SetDropdownFromRange(sheet, column, "Statuses!A1:A5"))
+	assert.Equal(map[string]interface{}{
+		"condition": map[string]interface{}{"type": "ONE_OF_RANGE", "values": []interface{}{
+			map[string]interface{}{"userEnteredValue": "=Statuses!A1:A5"},
+		}},
+		"showCustomUi": true,
+	}, rule)
+	assert.Error(s.SetDropdown(sheet, column, nil))
+}
@@ -0,0 +1,184 @@
+package spreadsheet
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Usage summarizes the API usage of a service, e.g. to log a single line at the end of a job.
+type Usage struct {
+	// Calls is the number of API calls by endpoint, e.g. "GET /spreadsheets/{spreadsheetId}".
+	Calls        map[string]int
+	CellsRead    int
+	CellsWritten int
+	// Retries is the number of calls sent again after a failure.
+	Retries int
+	// APITime is the total time spent waiting for the API.
+	APITime time.Duration
+}
+
+// TotalCalls returns the number of API calls to all the endpoints.
+func (u Usage) TotalCalls() (calls int) {
+	for _, n := range u.Calls {
+		calls += n
+	}
+	return
+}
+
+// String formats the usage as a single line, with the endpoints sorted by the number of calls.
+func (u Usage) String() string {
+	endpoints := make([]string, 0, len(u.Calls))
+	for endpoint := range u.Calls {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Slice(endpoints, func(i, j int) bool {
+		ci, cj := u.Calls[endpoints[i]], u.Calls[endpoints[j]]
+		return ci > cj || ci == cj && endpoints[i] < endpoints[j]
+	})
+	calls := make([]string, len(endpoints))
+	for i, endpoint := range endpoints {
+		calls[i] = fmt.Sprintf("%s=%d", endpoint, u.Calls[endpoint])
+	}
+	return fmt.Sprintf("calls=%d [%s] cells_read=%d cells_written=%d retries=%d api_time=%s",
+		u.TotalCalls(), strings.Join(calls, ", "), u.CellsRead, u.CellsWritten, u.Retries, u.APITime)
+}
+
+// Usage returns the API usage of the service since it was made or since ResetUsage.
+func (s *Service) Usage() Usage {
+	return s.usage.snapshot()
+}
+
+// ResetUsage clears the API usage of the service.
+func (s *Service) ResetUsage() {
+	s.usage.reset()
+}
+
+// usageRecorder collects the usage of a service. The zero value is ready to use.
+type usageRecorder struct {
+	mu    sync.Mutex
+	usage Usage
+}
+
+func (r *usageRecorder) call(method, rawURL string, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.usage.Calls == nil {
+		r.usage.Calls = map[string]int{}
+	}
+	r.usage.Calls[endpoint(method, rawURL)]++
+	r.usage.APITime += d
+}
+
+func (r *usageRecorder) cells(read, written int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usage.CellsRead += read
+	r.usage.CellsWritten += written
+}
+
+func (r *usageRecorder) retry() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usage.Retries++
+}
+
+func (r *usageRecorder) snapshot() (usage Usage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	usage = r.usage
+	usage.Calls = make(map[string]int, len(r.usage.Calls))
+	for endpoint, n := range r.usage.Calls {
+		usage.Calls[endpoint] = n
+	}
+	return
+}
+
+func (r *usageRecorder) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.usage = Usage{}
+}
+
+// endpointIDs are the placeholders of the path segments following the collections.
+var endpointIDs = map[string]string{
+	"spreadsheets":      "{spreadsheetId}",
+	"sheets":            "{sheetId}",
+	"values":            "{range}",
+	"developerMetadata": "{metadataId}",
+	"files":             "{fileId}",
+}
+
+// endpointMethods are the custom methods which may follow an id, e.g. "{range}:append".
+var endpointMethods = map[string]bool{
+	"append":          true,
+	"batchUpdate":     true,
+	"clear":           true,
+	"copyTo":          true,
+	"getByDataFilter": true,
+}
+
+// endpoint returns the method and the path of the URL with the ids replaced by placeholders,
+// so that the calls to the same endpoint are counted together.
+func endpoint(method, rawURL string) string {
+	name, _ := parseEndpoint(method, rawURL)
+	return name
+}
+
+// parseEndpoint returns the endpoint of the URL and the ids replaced in it,
+// by the names of their placeholders, e.g. "spreadsheetId" and "range".
+func parseEndpoint(method, rawURL string) (name string, ids map[string]string) {
+	ids = map[string]string{}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		name = method
+		return
+	}
+	segments := strings.Split(u.EscapedPath(), "/")
+	for i, segment := range segments {
+		if _, ok := endpointIDs[segment]; ok {
+			segments = segments[i:]
+			break
+		}
+	}
+	for i := 1; i < len(segments); i++ {
+		placeholder, ok := endpointIDs[segments[i-1]]
+		if !ok {
+			continue
+		}
+		id, custom := segments[i], ""
+		if j := strings.LastIndex(id, ":"); j >= 0 && endpointMethods[id[j+1:]] {
+			id, custom = id[:j], id[j:]
+		}
+		if unescaped, err := url.PathUnescape(id); err == nil {
+			id = unescaped
+		}
+		ids[strings.Trim(placeholder, "{}")] = id
+		segments[i] = placeholder + custom
+	}
+	name = method + " /" + strings.Join(segments, "/")
+	return
+}
+
+// countValues returns the number of values in the rows.
+func countValues(rows [][]interface{}) (n int) {
+	for _, row := range rows {
+		n += len(row)
+	}
+	return
+}
+
+// countGridCells returns the number of cells in the grid data of the sheets.
+func countGridCells(sheets []Sheet) (n int) {
+	for i := range sheets {
+		for _, gridData := range sheets[i].Data.GridData {
+			for _, row := range gridData.RowData {
+				n += len(row.Values)
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,88 @@
+package spreadsheet
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// jsonResponse returns a response with the status and the JSON body.
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestEndpoint(t *testing.T) {
+	assert := assert.New(t)
+	for rawURL, expected := range map[string]string{
+		baseURL + "/spreadsheets":                                  "GET /spreadsheets",
+		baseURL + "/spreadsheets/abc?fields=spreadsheetId":         "GET /spreadsheets/{spreadsheetId}",
+		baseURL + "/spreadsheets/abc:batchUpdate":                  "GET /spreadsheets/{spreadsheetId}:batchUpdate",
+		baseURL + "/spreadsheets/abc/values/Sheet1%21A1:B2":        "GET /spreadsheets/{spreadsheetId}/values/{range}",
+		baseURL + "/spreadsheets/abc/values/Sheet1%21A1:B2:append": "GET /spreadsheets/{spreadsheetId}/values/{range}:append",
+		baseURL + "/spreadsheets/abc/values:batchUpdate":           "GET /spreadsheets/{spreadsheetId}/values:batchUpdate",
+		baseURL + "/spreadsheets/abc/sheets/5:copyTo":              "GET /spreadsheets/{spreadsheetId}/sheets/{sheetId}:copyTo",
+		baseURL + "/spreadsheets/abc/developerMetadata/7":          "GET /spreadsheets/{spreadsheetId}/developerMetadata/{metadataId}",
+		baseURL + "/spreadsheets/abc/developerMetadata:search":     "GET /spreadsheets/{spreadsheetId}/developerMetadata:search",
+		driveBaseURL + "/files?q=mimeType%3D%27x%27&pageSize=10":   "GET /files",
+	} {
+		assert.Equal(expected, endpoint(http.MethodGet, rawURL), rawURL)
+	}
+}
+
+func TestUsageString(t *testing.T) {
+	assert := assert.New(t)
+	u := Usage{
+		Calls: map[string]int{
+			"GET /spreadsheets/{spreadsheetId}":                  1,
+			"PUT /spreadsheets/{spreadsheetId}/values/{range}":   3,
+			"POST /spreadsheets/{spreadsheetId}/values:batchGet": 1,
+		},
+		CellsRead:    10,
+		CellsWritten: 6,
+		APITime:      1500 * time.Millisecond,
+	}
+	assert.Equal(5, u.TotalCalls())
+	assert.Equal("calls=5 [PUT /spreadsheets/{spreadsheetId}/values/{range}=3, "+
+		"GET /spreadsheets/{spreadsheetId}=1, POST /spreadsheets/{spreadsheetId}/values:batchGet=1] "+
+		"cells_read=10 cells_written=6 retries=0 api_time=1.5s", u.String())
+}
+
+func TestServiceUsage(t *testing.T) {
+	assert := assert.New(t)
+	s := NewServiceWithClient(&http.Client{Transport: RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.Method == http.MethodPut {
+			return jsonResponse(http.StatusOK, `{"updatedCells":4}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"values":[["a","b"],["c"]]}`), nil
+	})})
+
+	_, err := s.GetValues("abc", "Sheet1!A1:B2")
+	assert.NoError(err)
+	_, err = s.GetValues("abc", "Sheet1!C1:D2")
+	assert.NoError(err)
+	_, err = s.UpdateValues("abc", "Sheet1!A1:B2", [][]interface{}{{1, 2}, {3, 4}})
+	assert.NoError(err)
+
+	u := s.Usage()
+	assert.Equal(map[string]int{
+		"GET /spreadsheets/{spreadsheetId}/values/{range}": 2,
+		"PUT /spreadsheets/{spreadsheetId}/values/{range}": 1,
+	}, u.Calls)
+	assert.Equal(3, u.TotalCalls())
+	assert.Equal(6, u.CellsRead)
+	assert.Equal(4, u.CellsWritten)
+
+	u.Calls["GET /files"] = 1
+	assert.Equal(3, s.Usage().TotalCalls())
+
+	s.ResetUsage()
+	assert.Equal(Usage{Calls: map[string]int{}}, s.Usage())
+}
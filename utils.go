@@ -1,5 +1,7 @@
 package spreadsheet
 
+import "fmt"
+
 func numberToLetter(num int) string {
 	if num <= 0 {
 		return ""
@@ -7,3 +9,19 @@ func numberToLetter(num int) string {
 
 	return numberToLetter(int((num-1)/26)) + string(byte(65+(num-1)%26))
 }
+
+// cellRange returns the A1 notation of the rows x columns range whose top
+// left cell is at the zero based row and column.
+func cellRange(row, column, rows, columns uint) string {
+	start := numberToLetter(int(column)+1) + fmt.Sprintf("%d", row+1)
+	if rows <= 1 && columns <= 1 {
+		return start
+	}
+	if rows == 0 {
+		rows = 1
+	}
+	if columns == 0 {
+		columns = 1
+	}
+	return start + ":" + numberToLetter(int(column+columns)) + fmt.Sprintf("%d", row+rows)
+}
@@ -17,6 +17,14 @@ func TestNumberToLetter(t *testing.T) {
 	assert.Equal("ZA", numberToLetter(677))
 }
 
+func TestCellRange(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("A1", cellRange(0, 0, 1, 1))
+	assert.Equal("B3", cellRange(2, 1, 0, 0))
+	assert.Equal("B3:D4", cellRange(2, 1, 2, 3))
+	assert.Equal("Z1:AA10", cellRange(0, 25, 10, 2))
+}
+
 func BenchmarkNumberToLetter(b *testing.B) {
 	b.ReportAllocs()
 	for i := 0; i < b.N; i++ {
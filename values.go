@@ -0,0 +1,559 @@
+package spreadsheet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ValueRange is data within a range of the spreadsheet.
+type ValueRange struct {
+	Range          string          `json:"range"`
+	MajorDimension Dimension       `json:"majorDimension"`
+	Values         [][]interface{} `json:"values"`
+}
+
+// GetValues fetches the values in the range without fetching the whole spreadsheet.
+func (s *Service) GetValues(spreadsheetID, a1Range string, opts ...CallOption) (valueRange ValueRange, err error) {
+	valueRange, err = s.GetValuesContext(context.Background(), spreadsheetID, a1Range, opts...)
+	return
+}
+
+// GetValuesContext is like GetValues with the context of the request.
+func (s *Service) GetValuesContext(ctx context.Context, spreadsheetID, a1Range string, opts ...CallOption) (valueRange ValueRange, err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	valueRange, err = s.getValues(ctx, spreadsheetID, a1Range, url.Values{}, opts)
+	return
+}
+
+// GetColumns fetches the values in the range grouped by column.
+func (s *Service) GetColumns(spreadsheetID, a1Range string, opts ...CallOption) (columns [][]string, err error) {
+	columns, err = s.GetColumnsContext(context.Background(), spreadsheetID, a1Range, opts...)
+	return
+}
+
+// GetColumnsContext is like GetColumns with the context of the request.
+func (s *Service) GetColumnsContext(ctx context.Context, spreadsheetID, a1Range string, opts ...CallOption) (columns [][]string, err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	opts = append(append([]CallOption{}, opts...), WithMajorDimension(DimensionColumns))
+	valueRange, err := s.getValues(ctx, spreadsheetID, a1Range, url.Values{}, opts)
+	if err != nil {
+		return
+	}
+	columns = toStrings(valueRange.Values)
+	return
+}
+
+// BatchGetValues fetches the values in the ranges with a single request.
+// The value ranges are returned in the same order as the ranges.
+func (s *Service) BatchGetValues(spreadsheetID string, ranges ...string) (valueRanges []ValueRange, err error) {
+	valueRanges, err = s.BatchGetValuesContext(context.Background(), spreadsheetID, ranges...)
+	return
+}
+
+// BatchGetValuesContext is like BatchGetValues with the context of the request.
+func (s *Service) BatchGetValuesContext(ctx context.Context, spreadsheetID string, ranges ...string) (valueRanges []ValueRange, err error) {
+	if len(ranges) == 0 {
+		err = errors.New("ranges must not be empty")
+		return
+	}
+	path := fmt.Sprintf("/spreadsheets/%s/values:batchGet?%s", spreadsheetID, url.Values{"ranges": ranges}.Encode())
+	body, err := s.get(ctx, path)
+	if err != nil {
+		return
+	}
+	var resp struct {
+		ValueRanges []ValueRange `json:"valueRanges"`
+	}
+	err = json.Unmarshal(body, &resp)
+	if err != nil {
+		return
+	}
+	valueRanges = resp.ValueRanges
+	for _, valueRange := range valueRanges {
+		s.usage.cells(countValues(valueRange.Values), 0)
+	}
+	return
+}
+
+// BatchGetValuesByDataFilter fetches the values in the ranges matching any of the data filters.
+func (s *Service) BatchGetValuesByDataFilter(spreadsheetID string, filters ...DataFilter) (valueRanges []MatchedValueRange, err error) {
+	valueRanges, err = s.BatchGetValuesByDataFilterContext(context.Background(), spreadsheetID, filters...)
+	return
+}
+
+// BatchGetValuesByDataFilterContext is like BatchGetValuesByDataFilter with the context of the request.
+func (s *Service) BatchGetValuesByDataFilterContext(ctx context.Context, spreadsheetID string, filters ...DataFilter) (valueRanges []MatchedValueRange, err error) {
+	if len(filters) == 0 {
+		err = errors.New("filters must not be empty")
+		return
+	}
+	path := fmt.Sprintf("/spreadsheets/%s/values:batchGetByDataFilter", spreadsheetID)
+	body, err := s.post(ctx, path, map[string]interface{}{
+		"dataFilters": filters,
+	})
+	if err != nil {
+		return
+	}
+	var resp struct {
+		ValueRanges []MatchedValueRange `json:"valueRanges"`
+	}
+	err = json.Unmarshal([]byte(body), &resp)
+	if err != nil {
+		return
+	}
+	valueRanges = resp.ValueRanges
+	for _, valueRange := range valueRanges {
+		s.usage.cells(countValues(valueRange.ValueRange.Values), 0)
+	}
+	return
+}
+
+// BatchUpdateValuesByDataFilter writes the values to the ranges matched by their data filters.
+func (s *Service) BatchUpdateValuesByDataFilter(spreadsheetID string, data []DataFilterValueRange, opts ...CallOption) (resp BatchUpdateValuesByDataFilterResponse, err error) {
+	resp, err = s.BatchUpdateValuesByDataFilterContext(context.Background(), spreadsheetID, data, opts...)
+	return
+}
+
+// BatchUpdateValuesByDataFilterContext is like BatchUpdateValuesByDataFilter with the context of the request.
+func (s *Service) BatchUpdateValuesByDataFilterContext(ctx context.Context, spreadsheetID string, data []DataFilterValueRange, opts ...CallOption) (resp BatchUpdateValuesByDataFilterResponse, err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	if len(data) == 0 {
+		err = errors.New("data must not be empty")
+		return
+	}
+	o := s.newCallOptions(opts)
+	if err = o.validate(); err != nil {
+		return
+	}
+	path := fmt.Sprintf("/spreadsheets/%s/values:batchUpdateByDataFilter", spreadsheetID)
+	params := map[string]interface{}{
+		"valueInputOption": o.valueInputOption,
+		"data":             data,
+	}
+	if o.includeValuesInResponse {
+		params["includeValuesInResponse"] = true
+		if o.responseValueRenderOption != "" {
+			params["responseValueRenderOption"] = o.responseValueRenderOption
+		}
+	}
+	body, err := s.post(ctx, path, params)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal([]byte(body), &resp)
+	if err != nil {
+		return
+	}
+	s.usage.cells(0, resp.TotalUpdatedCells)
+	return
+}
+
+func (s *Service) getValues(ctx context.Context, spreadsheetID, a1Range string, params url.Values, opts []CallOption) (valueRange ValueRange, err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	o := s.newCallOptions(opts)
+	if err = o.validate(); err != nil {
+		return
+	}
+	if o.valueRenderOption != "" {
+		params.Set("valueRenderOption", string(o.valueRenderOption))
+	}
+	if o.dateTimeRenderOption != "" {
+		params.Set("dateTimeRenderOption", string(o.dateTimeRenderOption))
+	}
+	if o.majorDimension != "" {
+		params.Set("majorDimension", string(o.majorDimension))
+	}
+	path := fmt.Sprintf("/spreadsheets/%s/values/%s", spreadsheetID, url.PathEscape(a1Range))
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	body, err := s.get(ctx, path)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(body, &valueRange)
+	if err != nil {
+		return
+	}
+	s.usage.cells(countValues(valueRange.Values), 0)
+	return
+}
+
+// UpdateValuesResponse is the response when updating a range of values.
+type UpdateValuesResponse struct {
+	SpreadsheetID  string `json:"spreadsheetId"`
+	UpdatedRange   string `json:"updatedRange"`
+	UpdatedRows    int    `json:"updatedRows"`
+	UpdatedColumns int    `json:"updatedColumns"`
+	UpdatedCells   int    `json:"updatedCells"`
+	// UpdatedData is the values stored by the server, when requested by WithValuesInResponse.
+	UpdatedData *ValueRange `json:"updatedData,omitempty"`
+}
+
+// UpdateValues writes the values to the range directly, without tracking them on a sheet.
+// The values are grouped by row unless WithMajorDimension is given.
+func (s *Service) UpdateValues(spreadsheetID, a1Range string, values [][]interface{}, opts ...CallOption) (resp UpdateValuesResponse, err error) {
+	resp, err = s.UpdateValuesContext(context.Background(), spreadsheetID, a1Range, values, opts...)
+	return
+}
+
+// UpdateValuesContext is like UpdateValues with the context of the request.
+func (s *Service) UpdateValuesContext(ctx context.Context, spreadsheetID, a1Range string, values [][]interface{}, opts ...CallOption) (resp UpdateValuesResponse, err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	resp, err = s.updateValues(ctx, spreadsheetID, ValueRange{
+		Range:          a1Range,
+		MajorDimension: s.newCallOptions(opts).majorDimensionOrRows(),
+		Values:         values,
+	}, opts...)
+	return
+}
+
+// AppendValuesResponse is the response when appending values.
+type AppendValuesResponse struct {
+	SpreadsheetID string `json:"spreadsheetId"`
+	// TableRange is the range of the table the values were appended to, before the append.
+	TableRange string               `json:"tableRange"`
+	Updates    UpdateValuesResponse `json:"updates"`
+}
+
+// AppendValues appends the values after the table found in the range.
+// The values are grouped by row unless WithMajorDimension is given.
+func (s *Service) AppendValues(spreadsheetID, a1Range string, values [][]interface{}, opts ...CallOption) (resp AppendValuesResponse, err error) {
+	resp, err = s.AppendValuesContext(context.Background(), spreadsheetID, a1Range, values, opts...)
+	return
+}
+
+// AppendValuesContext is like AppendValues with the context of the request.
+func (s *Service) AppendValuesContext(ctx context.Context, spreadsheetID, a1Range string, values [][]interface{}, opts ...CallOption) (resp AppendValuesResponse, err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	o := s.newCallOptions(opts)
+	if err = o.validate(); err != nil {
+		return
+	}
+	params := url.Values{"valueInputOption": {string(o.valueInputOption)}}
+	if o.insertDataOption != "" {
+		params.Set("insertDataOption", string(o.insertDataOption))
+	}
+	o.responseParams(params)
+	path := fmt.Sprintf("/spreadsheets/%s/values/%s:append?%s", spreadsheetID, url.PathEscape(a1Range), params.Encode())
+	body, err := s.post(ctx, path, map[string]interface{}{
+		"range":          a1Range,
+		"majorDimension": o.majorDimensionOrRows(),
+		"values":         values,
+	})
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal([]byte(body), &resp)
+	if err != nil {
+		return
+	}
+	s.usage.cells(0, resp.Updates.UpdatedCells)
+	return
+}
+
+// ClearValuesResponse is the response when clearing a range of values.
+type ClearValuesResponse struct {
+	SpreadsheetID string `json:"spreadsheetId"`
+	ClearedRange  string `json:"clearedRange"`
+}
+
+// ClearValues clears the values in the range. Formatting and data validation are kept.
+func (s *Service) ClearValues(spreadsheetID, a1Range string) (resp ClearValuesResponse, err error) {
+	resp, err = s.ClearValuesContext(context.Background(), spreadsheetID, a1Range)
+	return
+}
+
+// ClearValuesContext is like ClearValues with the context of the request.
+func (s *Service) ClearValuesContext(ctx context.Context, spreadsheetID, a1Range string) (resp ClearValuesResponse, err error) {
+	path := fmt.Sprintf("/spreadsheets/%s/values/%s:clear", spreadsheetID, url.PathEscape(a1Range))
+	body, err := s.post(ctx, path, map[string]interface{}{})
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal([]byte(body), &resp)
+	return
+}
+
+// BatchClearValuesResponse is the response when clearing ranges of values.
+type BatchClearValuesResponse struct {
+	SpreadsheetID string   `json:"spreadsheetId"`
+	ClearedRanges []string `json:"clearedRanges"`
+}
+
+// BatchClearValues clears the values in the ranges with a single request.
+func (s *Service) BatchClearValues(spreadsheetID string, ranges ...string) (resp BatchClearValuesResponse, err error) {
+	resp, err = s.BatchClearValuesContext(context.Background(), spreadsheetID, ranges...)
+	return
+}
+
+// BatchClearValuesContext is like BatchClearValues with the context of the request.
+func (s *Service) BatchClearValuesContext(ctx context.Context, spreadsheetID string, ranges ...string) (resp BatchClearValuesResponse, err error) {
+	if len(ranges) == 0 {
+		err = errors.New("ranges must not be empty")
+		return
+	}
+	path := fmt.Sprintf("/spreadsheets/%s/values:batchClear", spreadsheetID)
+	body, err := s.post(ctx, path, map[string]interface{}{
+		"ranges": ranges,
+	})
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal([]byte(body), &resp)
+	return
+}
+
+// BatchClearValuesByDataFilter clears the values in the ranges matching any of the data filters.
+func (s *Service) BatchClearValuesByDataFilter(spreadsheetID string, filters ...DataFilter) (resp BatchClearValuesResponse, err error) {
+	resp, err = s.BatchClearValuesByDataFilterContext(context.Background(), spreadsheetID, filters...)
+	return
+}
+
+// BatchClearValuesByDataFilterContext is like BatchClearValuesByDataFilter with the context of the request.
+func (s *Service) BatchClearValuesByDataFilterContext(ctx context.Context, spreadsheetID string, filters ...DataFilter) (resp BatchClearValuesResponse, err error) {
+	if len(filters) == 0 {
+		err = errors.New("filters must not be empty")
+		return
+	}
+	path := fmt.Sprintf("/spreadsheets/%s/values:batchClearByDataFilter", spreadsheetID)
+	body, err := s.post(ctx, path, map[string]interface{}{
+		"dataFilters": filters,
+	})
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal([]byte(body), &resp)
+	return
+}
+
+func (s *Service) updateValues(ctx context.Context, spreadsheetID string, valueRange ValueRange, opts ...CallOption) (resp UpdateValuesResponse, err error) {
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	o := s.newCallOptions(opts)
+	if err = o.validate(); err != nil {
+		return
+	}
+	params := url.Values{"valueInputOption": {string(o.valueInputOption)}}
+	o.responseParams(params)
+	path := fmt.Sprintf("/spreadsheets/%s/values/%s?%s", spreadsheetID, url.PathEscape(valueRange.Range), params.Encode())
+	body, err := s.put(ctx, path, map[string]interface{}{
+		"range":          valueRange.Range,
+		"majorDimension": valueRange.MajorDimension,
+		"values":         valueRange.Values,
+	})
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal([]byte(body), &resp)
+	if err != nil {
+		return
+	}
+	s.usage.cells(0, resp.UpdatedCells)
+	return
+}
+
+// GetValues fetches the values in the range of the sheet.
+func (sheet *Sheet) GetValues(a1Range string, opts ...CallOption) (valueRange ValueRange, err error) {
+	valueRange, err = sheet.GetValuesContext(context.Background(), a1Range, opts...)
+	return
+}
+
+// GetValuesContext is like GetValues with the context of the request.
+func (sheet *Sheet) GetValuesContext(ctx context.Context, a1Range string, opts ...CallOption) (valueRange ValueRange, err error) {
+	valueRange, err = sheet.Spreadsheet.service.GetValuesContext(ctx, sheet.Spreadsheet.ID, sheet.a1Range(a1Range), sheet.callOptions(opts)...)
+	return
+}
+
+// AppendValues appends the values after the last row of the sheet.
+func (sheet *Sheet) AppendValues(values [][]interface{}, opts ...CallOption) (resp AppendValuesResponse, err error) {
+	resp, err = sheet.AppendValuesContext(context.Background(), values, opts...)
+	return
+}
+
+// AppendValuesContext is like AppendValues with the context of the request.
+func (sheet *Sheet) AppendValuesContext(ctx context.Context, values [][]interface{}, opts ...CallOption) (resp AppendValuesResponse, err error) {
+	resp, err = sheet.Spreadsheet.service.AppendValuesContext(ctx, sheet.Spreadsheet.ID, sheet.a1Range(""), values, sheet.callOptions(opts)...)
+	return
+}
+
+// ClearValues clears the values in the range of the sheet.
+// An empty range clears the whole sheet.
+func (sheet *Sheet) ClearValues(a1Range string) (resp ClearValuesResponse, err error) {
+	resp, err = sheet.ClearValuesContext(context.Background(), a1Range)
+	return
+}
+
+// ClearValuesContext is like ClearValues with the context of the request.
+func (sheet *Sheet) ClearValuesContext(ctx context.Context, a1Range string) (resp ClearValuesResponse, err error) {
+	resp, err = sheet.Spreadsheet.service.ClearValuesContext(ctx, sheet.Spreadsheet.ID, sheet.a1Range(a1Range))
+	return
+}
+
+// GetColumns fetches the values in the range of the sheet grouped by column.
+func (sheet *Sheet) GetColumns(a1Range string, opts ...CallOption) (columns [][]string, err error) {
+	columns, err = sheet.GetColumnsContext(context.Background(), a1Range, opts...)
+	return
+}
+
+// GetColumnsContext is like GetColumns with the context of the request.
+func (sheet *Sheet) GetColumnsContext(ctx context.Context, a1Range string, opts ...CallOption) (columns [][]string, err error) {
+	columns, err = sheet.Spreadsheet.service.GetColumnsContext(ctx, sheet.Spreadsheet.ID, sheet.a1Range(a1Range), sheet.callOptions(opts)...)
+	return
+}
+
+// DefaultChunkRows is the number of rows of the chunks of GetValuesInChunks when none is given.
+const DefaultChunkRows = 10000
+
+// ValueChunk is a chunk of rows of a sheet.
+type ValueChunk struct {
+	// StartRow is the zero based index of the first row of the chunk in the sheet.
+	StartRow int
+	Values   [][]interface{}
+}
+
+// GetValuesInChunks fetches the values of the sheet by chunks of chunkRows rows, a request each, and passes them
+// to fn as they come, so that huge sheets are processed without holding all their values in memory.
+// Chunks without values are skipped. The rows of the grid are read up to Properties.GridProperties.RowCount.
+// It stops at the first error, of a request or returned by fn.
+func (sheet *Sheet) GetValuesInChunks(chunkRows int, fn func(chunk ValueChunk) error, opts ...CallOption) (err error) {
+	err = sheet.GetValuesInChunksContext(context.Background(), chunkRows, fn, opts...)
+	return
+}
+
+// GetValuesInChunksContext is like GetValuesInChunks with the context of the requests.
+func (sheet *Sheet) GetValuesInChunksContext(ctx context.Context, chunkRows int, fn func(chunk ValueChunk) error, opts ...CallOption) (err error) {
+	if chunkRows <= 0 {
+		chunkRows = DefaultChunkRows
+	}
+	s := sheet.Spreadsheet.service
+	opts = append(append([]CallOption{}, sheet.callOptions(opts)...), WithMajorDimension(DimensionRows))
+	ctx, cancel := s.withCallOptions(ctx, opts)
+	defer cancel()
+	rowCount := int(sheet.Properties.GridProperties.RowCount)
+	for start := 0; start < rowCount; start += chunkRows {
+		a1Range := sheet.a1Range(fmt.Sprintf("%d:%d", start+1, start+chunkRows))
+		var valueRange ValueRange
+		valueRange, err = s.getValues(ctx, sheet.Spreadsheet.ID, a1Range, url.Values{}, opts)
+		if err != nil {
+			return
+		}
+		if len(valueRange.Values) == 0 {
+			continue
+		}
+		if err = fn(ValueChunk{StartRow: start, Values: valueRange.Values}); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// a1Range qualifies the range with the title of the sheet.
+func (sheet *Sheet) a1Range(a1Range string) string {
+	title := quoteSheetTitle(sheet.Properties.Title)
+	if a1Range == "" {
+		return title
+	}
+	return title + "!" + a1Range
+}
+
+// quoteSheetTitle quotes the title to be used in A1 notation.
+func quoteSheetTitle(title string) string {
+	return "'" + strings.Replace(title, "'", "''", -1) + "'"
+}
+
+// unquotedSheetTitle matches titles which can be referred to without quotes.
+var unquotedSheetTitle = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// formulaReferencePattern returns a regular expression matching references
+// to the sheet with the title in formulas, e.g. 'My Sheet'! or Sheet1!.
+func formulaReferencePattern(title string) string {
+	pattern := regexp.QuoteMeta(quoteSheetTitle(title) + "!")
+	if unquotedSheetTitle.MatchString(title) {
+		pattern += `|\b` + regexp.QuoteMeta(title+"!")
+	}
+	return pattern
+}
+
+func toStrings(values [][]interface{}) [][]string {
+	ret := make([][]string, len(values))
+	for i, vs := range values {
+		ret[i] = make([]string, len(vs))
+		for j, v := range vs {
+			if v != nil {
+				ret[i][j] = fmt.Sprint(v)
+			}
+		}
+	}
+	return ret
+}
+
+// UpdateRangeTransposed writes the column major values to the sheet, starting
+// at the zero based row and column, without transposing them locally.
+func (sheet *Sheet) UpdateRangeTransposed(startRow, startCol int, columns [][]interface{}, opts ...CallOption) (err error) {
+	err = sheet.UpdateRangeTransposedContext(context.Background(), startRow, startCol, columns, opts...)
+	return
+}
+
+// UpdateRangeTransposedContext is like UpdateRangeTransposed with the context of the request.
+func (sheet *Sheet) UpdateRangeTransposedContext(ctx context.Context, startRow, startCol int, columns [][]interface{}, opts ...CallOption) (err error) {
+	if startRow < 0 || startCol < 0 {
+		err = errors.New("start row and column must not be negative")
+		return
+	}
+	if len(columns) == 0 {
+		return
+	}
+	var rows int
+	for _, column := range columns {
+		if len(column) > rows {
+			rows = len(column)
+		}
+	}
+	a1Range := cellRange(uint(startRow), uint(startCol), uint(rows), uint(len(columns)))
+	_, err = sheet.Spreadsheet.service.updateValues(ctx, sheet.Spreadsheet.ID, ValueRange{
+		Range:          sheet.a1Range(a1Range),
+		MajorDimension: DimensionColumns,
+		Values:         columns,
+	}, sheet.callOptions(opts)...)
+	return
+}
+
+// SheetRef refers to a sheet of a spreadsheet by its title,
+// without needing to fetch the spreadsheet.
+type SheetRef struct {
+	SpreadsheetID string
+	Title         string
+}
+
+// Ref returns the reference to the sheet.
+func (sheet *Sheet) Ref() SheetRef {
+	return SheetRef{SpreadsheetID: sheet.Spreadsheet.ID, Title: sheet.Properties.Title}
+}
+
+// AtomicAppend appends the rows after the last row of the table in the sheet.
+// Rows are always inserted and the position is decided by the server alone,
+// so multiple processes can append to the same sheet concurrently.
+// The cached cells of a fetched sheet are not updated.
+func (s *Service) AtomicAppend(ref SheetRef, rows [][]interface{}, opts ...CallOption) (resp AppendValuesResponse, err error) {
+	resp, err = s.AtomicAppendContext(context.Background(), ref, rows, opts...)
+	return
+}
+
+// AtomicAppendContext is like AtomicAppend with the context of the request.
+func (s *Service) AtomicAppendContext(ctx context.Context, ref SheetRef, rows [][]interface{}, opts ...CallOption) (resp AppendValuesResponse, err error) {
+	opts = append(append([]CallOption{}, opts...), WithInsertDataOption(InsertDataInsertRows), WithMajorDimension(DimensionRows))
+	resp, err = s.AppendValuesContext(ctx, ref.SpreadsheetID, quoteSheetTitle(ref.Title), rows, opts...)
+	return
+}
@@ -0,0 +1,190 @@
+package spreadsheet
+
+import (
+	"compress/gzip"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToStrings(t *testing.T) {
+	assert := assert.New(t)
+	values := [][]interface{}{
+		{"a", 1.5, true},
+		{},
+		{nil, "b"},
+	}
+	assert.Equal([][]string{
+		{"a", "1.5", "true"},
+		{},
+		{"", "b"},
+	}, toStrings(values))
+}
+
+func TestSheetA1Range(t *testing.T) {
+	assert := assert.New(t)
+	sheet := &Sheet{Properties: SheetProperties{Title: "Bob's sheet"}}
+	assert.Equal("'Bob''s sheet'!A1:B2", sheet.a1Range("A1:B2"))
+	assert.Equal("'Bob''s sheet'", sheet.a1Range(""))
+}
+
+func TestFormulaReferencePattern(t *testing.T) {
+	assert := assert.New(t)
+	re := regexp.MustCompile(formulaReferencePattern("Data"))
+	assert.Equal(`=SUM('New'!A1:A3)+INDIRECT("'New'!B1")+'New'!C1`,
+		re.ReplaceAllString(`=SUM(Data!A1:A3)+INDIRECT("'Data'!B1")+'Data'!C1`, "'New'!"))
+	assert.Equal(`=MyData!A1+'My Data'!A1+_Data!A1`,
+		re.ReplaceAllString(`=MyData!A1+'My Data'!A1+_Data!A1`, "'New'!"))
+
+	re = regexp.MustCompile(formulaReferencePattern("Bob's (2019)"))
+	assert.Equal(`='New'!A1`, re.ReplaceAllString(`='Bob''s (2019)'!A1`, "'New'!"))
+	assert.False(re.MatchString(`=Bob's (2019)!A1`))
+}
+
+func TestGetValuesWithBaseURL(t *testing.T) {
+	assert := assert.New(t)
+	var requestURI string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestURI = r.RequestURI
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"range":"Sheet1!A1:B1","values":[["a","b"]]}`))
+	}))
+	defer server.Close()
+
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL+"/v4/"))
+	valueRange, err := s.GetValues("abc", "Sheet1!A1:B1")
+	assert.NoError(err)
+	assert.Equal("/v4/spreadsheets/abc/values/Sheet1%21A1:B1", requestURI)
+	assert.Equal([][]interface{}{{"a", "b"}}, valueRange.Values)
+}
+
+func TestGetValuesGzip(t *testing.T) {
+	assert := assert.New(t)
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			w.Write([]byte(`{"values":[["plain"]]}`))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`{"values":[["gzipped"]]}`))
+		gz.Close()
+	}))
+	defer server.Close()
+
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	valueRange, err := s.GetValues("abc", "A1")
+	assert.NoError(err)
+	assert.Equal([][]interface{}{{"gzipped"}}, valueRange.Values)
+	assert.Contains(userAgent, "gzip")
+}
+
+func TestCallsKeepTheOptionsOfTheCaller(t *testing.T) {
+	assert := assert.New(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values":[["a"]]}`))
+	}))
+	defer server.Close()
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+
+	// the spare capacity of the options of the caller isn't written
+	spare := WithTimeout(time.Minute)
+	opts := make([]CallOption, 1, 3)
+	opts[0] = WithValueRenderOption(ValueRenderFormatted)
+	opts = append(opts, spare)[:1]
+	_, err := s.GetColumns("abc", "A1", opts...)
+	assert.NoError(err)
+	_, err = s.AtomicAppend(SheetRef{SpreadsheetID: "abc", Title: "s"}, [][]interface{}{{1}}, opts...)
+	assert.NoError(err)
+	o := newCallOptions(opts[:2])
+	assert.Equal(time.Minute, o.timeout)
+	assert.Empty(o.majorDimension)
+	assert.Empty(o.insertDataOption)
+}
+
+func TestGetValuesWithAPIKey(t *testing.T) {
+	assert := assert.New(t)
+	var (
+		query url.Values
+		key   string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		key = r.Header.Get("X-Goog-Api-Key")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"values":[["public"]]}`))
+	}))
+	defer server.Close()
+
+	s := NewServiceWithAPIKey("secret", WithBaseURL(server.URL))
+	valueRange, err := s.GetValues("abc", "A1", WithValueRenderOption(ValueRenderUnformatted))
+	assert.NoError(err)
+	assert.Equal([][]interface{}{{"public"}}, valueRange.Values)
+	assert.Equal(url.Values{"valueRenderOption": {"UNFORMATTED_VALUE"}}, query)
+	assert.Equal("secret", key)
+
+	// the key isn't in the errors of the requests
+	s = NewServiceWithAPIKey("secret", WithBaseURL("http://127.0.0.1:0"), WithRetryPolicy(RetryPolicy{}))
+	_, err = s.GetValues("abc", "A1")
+	if assert.Error(err) {
+		assert.NotContains(err.Error(), "secret")
+	}
+}
+
+func TestGetValuesInChunks(t *testing.T) {
+	assert := assert.New(t)
+	var ranges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a1Range, _ := url.PathUnescape(r.URL.EscapedPath()[len("/spreadsheets/abc/values/"):])
+		ranges = append(ranges, a1Range)
+		assert.Equal("ROWS", r.URL.Query().Get("majorDimension"))
+		w.Header().Set("Content-Type", "application/json")
+		switch a1Range {
+		case "'s'!1:2":
+			w.Write([]byte(`{"values":[["a"],["b"]]}`))
+		case "'s'!5:6":
+			w.Write([]byte(`{"values":[[],["f"]]}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	s := NewServiceWithClient(server.Client(), WithBaseURL(server.URL))
+	spreadsheet := &Spreadsheet{ID: "abc", service: s, Sheets: []Sheet{{Properties: SheetProperties{
+		Title:          "s",
+		GridProperties: GridProperties{RowCount: 7, ColumnCount: 1},
+	}}}}
+	sheet := &spreadsheet.Sheets[0]
+	sheet.Spreadsheet = spreadsheet
+
+	var chunks []ValueChunk
+	err := sheet.GetValuesInChunks(2, func(chunk ValueChunk) error {
+		chunks = append(chunks, chunk)
+		return nil
+	}, WithMajorDimension(DimensionColumns))
+	assert.NoError(err)
+	assert.Equal([]string{"'s'!1:2", "'s'!3:4", "'s'!5:6", "'s'!7:8"}, ranges)
+	assert.Equal([]ValueChunk{
+		{StartRow: 0, Values: [][]interface{}{{"a"}, {"b"}}},
+		{StartRow: 4, Values: [][]interface{}{{}, {"f"}}},
+	}, chunks)
+
+	ranges = nil
+	stop := errors.New("stop")
+	err = sheet.GetValuesInChunks(2, func(chunk ValueChunk) error {
+		return stop
+	})
+	assert.Equal(stop, err)
+	assert.Len(ranges, 1)
+}
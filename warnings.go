@@ -0,0 +1,130 @@
+package spreadsheet
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+// WarningKind is the kind of a warning.
+type WarningKind string
+
+// Warning kinds.
+const (
+	// WarningDataDeleted is emitted when deleting rows or columns deletes non-empty cells.
+	WarningDataDeleted WarningKind = "DATA_DELETED"
+	// WarningChangesDiscarded is emitted when deleting rows or columns discards changes not synchronized yet.
+	WarningChangesDiscarded WarningKind = "CHANGES_DISCARDED"
+	// WarningValueCoerced is emitted when a value written as USER_ENTERED is likely stored differently,
+	// e.g. "007" stored as the number 7.
+	WarningValueCoerced WarningKind = "VALUE_COERCED"
+	// WarningValueTooLong is emitted when a value exceeds the maximum length of a cell.
+	WarningValueTooLong WarningKind = "VALUE_TOO_LONG"
+)
+
+// maxCellLength is the maximum number of characters in a cell.
+const maxCellLength = 50000
+
+// Warning describes an operation which may have lost data.
+type Warning struct {
+	Kind WarningKind
+	// Sheet is the title of the sheet.
+	Sheet string
+	// Range is the affected range of the sheet in A1 notation.
+	Range   string
+	Message string
+}
+
+func (w Warning) String() string {
+	return fmt.Sprintf("%s: %s!%s: %s", w.Kind, w.Sheet, w.Range, w.Message)
+}
+
+// SetWarningHandler sets the function called with warnings about operations which may lose data.
+// Warnings are ignored when no handler is set.
+func (s *Service) SetWarningHandler(handler func(Warning)) {
+	s.warningHandler = handler
+}
+
+func (s *Service) warn(w Warning) {
+	if s.warningHandler != nil {
+		s.warningHandler(w)
+	}
+}
+
+var (
+	leadingZeros = regexp.MustCompile(`^[+-]?0[0-9]+$`)
+	longNumber   = regexp.MustCompile(`^[+-]?[0-9]{16,}$`)
+)
+
+// valueWarning returns the warning about writing the value to the cell, if any.
+func valueWarning(sheet *Sheet, cell *Cell, option ValueInputOption) (w Warning, ok bool) {
+	value := cell.Value
+	w = Warning{Sheet: sheet.Properties.Title, Range: cell.Pos()}
+	switch {
+	case utf8.RuneCountInString(value) > maxCellLength:
+		w.Kind = WarningValueTooLong
+		w.Message = fmt.Sprintf("value exceeds %d characters", maxCellLength)
+	case option != ValueInputUserEntered || cell.UserEnteredValue != nil:
+		return
+	case leadingZeros.MatchString(value):
+		w.Kind = WarningValueCoerced
+		w.Message = fmt.Sprintf("leading zeros of %q are dropped", value)
+	case longNumber.MatchString(value):
+		w.Kind = WarningValueCoerced
+		w.Message = fmt.Sprintf("digits of %q beyond 15 are lost", value)
+	default:
+		return
+	}
+	ok = true
+	return
+}
+
+// deletionWarnings returns the warnings about deleting rows or columns [start, end) of the sheet.
+func deletionWarnings(sheet *Sheet, dimension Dimension, start, end int) (warnings []Warning) {
+	sheet.mu.Lock()
+	defer sheet.mu.Unlock()
+	var a1Range string
+	if dimension == DimensionColumns {
+		a1Range = numberToLetter(start+1) + ":" + numberToLetter(end)
+	} else {
+		a1Range = fmt.Sprintf("%d:%d", start+1, end)
+	}
+	in := func(cell *Cell) bool {
+		i := int(cell.Row)
+		if dimension == DimensionColumns {
+			i = int(cell.Column)
+		}
+		return start <= i && i < end
+	}
+	cells := 0
+	for _, row := range sheet.Rows {
+		for i := range row {
+			if row[i].Value != "" && in(&row[i]) {
+				cells++
+			}
+		}
+	}
+	if cells > 0 {
+		warnings = append(warnings, Warning{
+			Kind:    WarningDataDeleted,
+			Sheet:   sheet.Properties.Title,
+			Range:   a1Range,
+			Message: fmt.Sprintf("%d non-empty cells are deleted", cells),
+		})
+	}
+	changes := 0
+	for _, cell := range sheet.modifiedCells {
+		if in(cell) {
+			changes++
+		}
+	}
+	if changes > 0 {
+		warnings = append(warnings, Warning{
+			Kind:    WarningChangesDiscarded,
+			Sheet:   sheet.Properties.Title,
+			Range:   a1Range,
+			Message: fmt.Sprintf("%d changes not synchronized yet are discarded", changes),
+		})
+	}
+	return
+}
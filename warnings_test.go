@@ -0,0 +1,63 @@
+package spreadsheet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValueWarning(t *testing.T) {
+	assert := assert.New(t)
+	sheet := &Sheet{Properties: SheetProperties{Title: "s"}}
+	warning := func(value string, option ValueInputOption) (WarningKind, string) {
+		w, ok := valueWarning(sheet, &Cell{Row: 1, Column: 2, Value: value}, option)
+		if !ok {
+			return "", ""
+		}
+		assert.Equal("s", w.Sheet)
+		assert.Equal("C2", w.Range)
+		return w.Kind, w.Message
+	}
+	kind, message := warning("007", ValueInputUserEntered)
+	assert.Equal(WarningValueCoerced, kind)
+	assert.Equal(`leading zeros of "007" are dropped`, message)
+	kind, _ = warning("1234567890123456", ValueInputUserEntered)
+	assert.Equal(WarningValueCoerced, kind)
+	kind, _ = warning(strings.Repeat("a", maxCellLength+1), ValueInputRaw)
+	assert.Equal(WarningValueTooLong, kind)
+
+	for _, value := range []string{"007", "0", "0.5", "123", "text"} {
+		kind, _ = warning(value, ValueInputRaw)
+		assert.Empty(kind, value)
+	}
+	for _, value := range []string{"0", "0.5", "-12", "text", strings.Repeat("a", maxCellLength)} {
+		kind, _ = warning(value, ValueInputUserEntered)
+		assert.Empty(kind, value)
+	}
+}
+
+func TestDeletionWarnings(t *testing.T) {
+	assert := assert.New(t)
+	sheet := newTestSheet("s", 0, []string{"a", "b"}, []string{"", "c"}, []string{"d"})
+	sheet.modifiedCells = nil
+	sheet.Update(1, 0, "e")
+	assert.Equal([]Warning{
+		{Kind: WarningDataDeleted, Sheet: "s", Range: "1:2", Message: "4 non-empty cells are deleted"},
+		{Kind: WarningChangesDiscarded, Sheet: "s", Range: "1:2", Message: "1 changes not synchronized yet are discarded"},
+	}, deletionWarnings(&sheet, "ROWS", 0, 2))
+	assert.Equal([]Warning{
+		{Kind: WarningDataDeleted, Sheet: "s", Range: "B:B", Message: "2 non-empty cells are deleted"},
+	}, deletionWarnings(&sheet, "COLUMNS", 1, 2))
+	assert.Empty(deletionWarnings(&sheet, "ROWS", 5, 7))
+}
+
+func TestWarn(t *testing.T) {
+	assert := assert.New(t)
+	s := &Service{}
+	s.warn(Warning{})
+	var warnings []Warning
+	s.SetWarningHandler(func(w Warning) { warnings = append(warnings, w) })
+	s.warn(Warning{Kind: WarningValueCoerced})
+	assert.Equal([]Warning{{Kind: WarningValueCoerced}}, warnings)
+}